@@ -0,0 +1,19 @@
+// +build darwin,!js
+
+package glfw
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+static int onActiveSpace(void *window) {
+	return [(NSWindow *)window isOnActiveSpace];
+}
+*/
+import "C"
+
+// onActiveDesktop reports whether the window is on the active space. Must be called on the render thread.
+func onActiveDesktop(w *Window) bool {
+	return C.onActiveSpace(w.Window.GetCocoaWindow()) != 0
+}
@@ -0,0 +1,17 @@
+// +build !js
+
+package glfw
+
+// IsOnActiveDesktop reports whether the window is on the active virtual desktop (workspace or space).
+//
+// A window on an inactive virtual desktop may still have input focus in glfw terms, but isn't visible,
+// so applications can use this to pause expensive rendering.
+// It is supported on macOS and X11, using native queries. It always returns true on other platforms,
+// and if the window manager doesn't report virtual desktops.
+func (w *Window) IsOnActiveDesktop() bool {
+	active := true
+	enqueue(true, func() {
+		active = onActiveDesktop(w)
+	})
+	return active
+}
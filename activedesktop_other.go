@@ -0,0 +1,10 @@
+// +build !js
+// +build !darwin
+// +build wayland !linux,!freebsd
+
+package glfw
+
+// onActiveDesktop always returns true, as virtual desktops can't be queried on this platform.
+func onActiveDesktop(w *Window) bool {
+	return true
+}
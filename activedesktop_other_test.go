@@ -0,0 +1,14 @@
+// +build !js
+// +build !darwin
+// +build wayland !linux,!freebsd
+
+package glfw
+
+import "testing"
+
+func TestIsOnActiveDesktopDefault(t *testing.T) {
+	defer stubEnqueue()()
+	if !new(Window).IsOnActiveDesktop() {
+		t.Error("got false on a platform without virtual desktop queries, want true")
+	}
+}
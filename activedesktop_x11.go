@@ -0,0 +1,54 @@
+// +build linux,!wayland,!js freebsd,!wayland,!js
+
+package glfw
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+#include <X11/Xatom.h>
+
+// cardinalProperty reads a single CARDINAL property of a window. Returns 0 if it is not set.
+static int cardinalProperty(Display *display, Window window, const char *name, unsigned long *value) {
+	Atom atom = XInternAtom(display, name, True);
+	if (atom == None) {
+		return 0;
+	}
+	Atom type;
+	int format;
+	unsigned long count, remaining;
+	unsigned char *data = NULL;
+	if (XGetWindowProperty(display, window, atom, 0, 1, False, XA_CARDINAL, &type, &format, &count, &remaining, &data) != Success) {
+		return 0;
+	}
+	int ok = data != NULL && type == XA_CARDINAL && format == 32 && count == 1;
+	if (ok) {
+		*value = *(unsigned long *)data & 0xFFFFFFFF;
+	}
+	if (data != NULL) {
+		XFree(data);
+	}
+	return ok;
+}
+
+static int onActiveDesktop(Display *display, Window window) {
+	unsigned long current, desktop;
+	if (!cardinalProperty(display, DefaultRootWindow(display), "_NET_CURRENT_DESKTOP", &current) ||
+		!cardinalProperty(display, window, "_NET_WM_DESKTOP", &desktop)) {
+		return 1;
+	}
+	return desktop == current || desktop == 0xFFFFFFFF; // All desktops.
+}
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// onActiveDesktop compares the window's desktop with the current one, as reported by the EWMH properties of the window manager.
+// Must be called on the render thread.
+func onActiveDesktop(w *Window) bool {
+	display := (*C.Display)(unsafe.Pointer(glfw.GetX11Display()))
+	return C.onActiveDesktop(display, C.Window(w.Window.GetX11Window())) != 0
+}
@@ -0,0 +1,8 @@
+// +build !js
+// +build !windows
+// +build wayland !linux,!freebsd
+
+package glfw
+
+// clearAttention does nothing, as the platform ends attention requests when the application is activated.
+func clearAttention(w *Window) {}
@@ -0,0 +1,19 @@
+// +build windows,!js
+
+package glfw
+
+/*
+#include <windows.h>
+
+static void clearAttention(HWND window) {
+	FLASHWINFO info = {sizeof(FLASHWINFO), window, FLASHW_STOP, 0, 0};
+	FlashWindowEx(&info);
+}
+*/
+import "C"
+import "unsafe"
+
+// clearAttention stops flashing the taskbar entry of the window. Must be called on the render thread.
+func clearAttention(w *Window) {
+	C.clearAttention(C.HWND(unsafe.Pointer(w.Window.GetWin32Window())))
+}
@@ -0,0 +1,40 @@
+// +build linux,!wayland,!js freebsd,!wayland,!js
+
+package glfw
+
+/*
+#cgo LDFLAGS: -lX11
+#include <X11/Xlib.h>
+
+// clearAttention removes the _NET_WM_STATE_DEMANDS_ATTENTION state set by glfwRequestWindowAttention.
+static void clearAttention(Display *display, Window window) {
+	Atom state = XInternAtom(display, "_NET_WM_STATE", True);
+	Atom demandsAttention = XInternAtom(display, "_NET_WM_STATE_DEMANDS_ATTENTION", True);
+	if (state == None || demandsAttention == None) {
+		return;
+	}
+	XEvent event = {0};
+	event.type = ClientMessage;
+	event.xclient.window = window;
+	event.xclient.message_type = state;
+	event.xclient.format = 32;
+	event.xclient.data.l[0] = 0; // _NET_WM_STATE_REMOVE
+	event.xclient.data.l[1] = demandsAttention;
+	event.xclient.data.l[3] = 1; // Normal application.
+	XSendEvent(display, DefaultRootWindow(display), False, SubstructureNotifyMask | SubstructureRedirectMask, &event);
+	XFlush(display);
+}
+*/
+import "C"
+import (
+	"unsafe"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// clearAttention clears the urgency of the window, as not all window managers do so when the window is focused.
+// Must be called on the render thread.
+func clearAttention(w *Window) {
+	display := (*C.Display)(unsafe.Pointer(glfw.GetX11Display()))
+	C.clearAttention(display, C.Window(w.Window.GetX11Window()))
+}
@@ -7,8 +7,10 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"math"
 	"net/http"
 	"runtime"
+	"sort"
 
 	"github.com/gopherjs/gopherjs/js"
 	"honnef.co/go/js/dom"
@@ -283,6 +285,9 @@ func CreateWindow(_, _ int, title string, monitor *Monitor, share *Window) (*Win
 
 	// Request first animation frame.
 	js.Global.Call("requestAnimationFrame", animationFrame)
+	if measuredRefreshRate == 0 {
+		measureRefreshRate()
+	}
 
 	return w, nil
 }
@@ -351,7 +356,7 @@ func (m *Monitor) GetVideoMode() *VidMode {
 		RedBits:     8,
 		GreenBits:   8,
 		BlueBits:    8,
-		RefreshRate: 60,
+		RefreshRate: refreshRate(),
 	}
 }
 
@@ -360,6 +365,71 @@ func GetPrimaryMonitor() *Monitor {
 	return &Monitor{}
 }
 
+// ContentScale returns the content scale of the monitor, which is the browser's device pixel ratio.
+func (m *Monitor) ContentScale() (x, y float32) {
+	ratio := float32(js.Global.Get("devicePixelRatio").Float())
+	return ratio, ratio
+}
+
+// RefreshRate returns the refresh rate, in Hz, of the monitor the window is on.
+//
+// The browser doesn't report it, so it is measured by timing animation frames once the first window is created.
+// Until the measurement has completed, which takes about half a second while the page is visible, 60 Hz is assumed.
+// The rate is measured only once, so moving the browser to a monitor with a different refresh rate isn't detected.
+func (w *Window) RefreshRate() int {
+	return refreshRate()
+}
+
+// refreshRateSamples is the number of animation frame intervals timed to measure the refresh rate.
+const refreshRateSamples = 30
+
+// measuredRefreshRate is the refresh rate measured by measureRefreshRate, or zero if not measured yet.
+var measuredRefreshRate int
+
+// refreshRate returns the measured refresh rate, or 60 Hz if it hasn't been measured yet.
+func refreshRate() int {
+	if measuredRefreshRate > 0 {
+		return measuredRefreshRate
+	}
+	return 60
+}
+
+// measureRefreshRate times consecutive animation frames, which browsers fire at the display's refresh rate,
+// and stores the rate derived from the median interval in measuredRefreshRate.
+// It runs its own animation frame loop, so that slow rendering doesn't skew the result.
+func measureRefreshRate() {
+	var timestamps []float64
+	var frame func(timestamp float64)
+	frame = func(timestamp float64) {
+		timestamps = append(timestamps, timestamp)
+		if len(timestamps) <= refreshRateSamples {
+			js.Global.Call("requestAnimationFrame", frame)
+			return
+		}
+
+		intervals := make([]float64, refreshRateSamples)
+		for i := range intervals {
+			intervals[i] = timestamps[i+1] - timestamps[i]
+		}
+		sort.Float64s(intervals)
+		if median := intervals[len(intervals)/2]; median > 0 {
+			measuredRefreshRate = int(math.Round(1000 / median))
+		}
+	}
+	js.Global.Call("requestAnimationFrame", frame)
+}
+
+// FindVideoMode returns the supported video mode closest to the requested one.
+// The browser only reports the current mode.
+func (m *Monitor) FindVideoMode(width, height, refreshRate int) *VidMode {
+	return nearestVideoMode([]VidMode{*m.GetVideoMode()}, width, height, refreshRate)
+}
+
+// PrimaryVideoMode returns the current video mode of the primary monitor.
+func PrimaryVideoMode() *VidMode {
+	return GetPrimaryMonitor().GetVideoMode()
+}
+
 func PollEvents() error {
 	return nil
 }
@@ -396,11 +466,11 @@ func (w *Window) SetMouseMovementCallback(cbfun MouseMovementCallback) (previous
 
 type KeyCallback func(w *Window, key Key, scancode int, action Action, mods ModifierKey)
 
+// SetKeyCallback sets the key callback and returns the previously set one, or nil if there was none.
 func (w *Window) SetKeyCallback(cbfun KeyCallback) (previous KeyCallback) {
+	previous = w.keyCallback
 	w.keyCallback = cbfun
-
-	// TODO: Handle previous.
-	return nil
+	return previous
 }
 
 type CharCallback func(w *Window, char rune)
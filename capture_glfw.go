@@ -0,0 +1,150 @@
+// +build !js
+
+package glfw
+
+import (
+	"errors"
+	"fmt"
+	"image"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// ReadPixelsFunc reads a region of the current context's default framebuffer into pixels.
+//
+// It must behave like glReadPixels(x, y, width, height, GL_RGBA, GL_UNSIGNED_BYTE, pixels)
+// with a pack alignment of 1: rows are tightly packed and the bottom row comes first.
+// Implementations should wait for rendering to finish (glReadPixels does so implicitly).
+type ReadPixelsFunc func(x, y, width, height int, pixels []byte)
+
+// readPixels is used by CaptureFramebuffer. Only accessed on the render thread.
+var readPixels ReadPixelsFunc
+
+// SetReadPixelsFunc registers the function used by CaptureFramebuffer to read back framebuffers.
+// It should be provided by the GL bindings you are using, typically right after Init.
+// Passing nil unregisters the function.
+func SetReadPixelsFunc(fn ReadPixelsFunc) {
+	enqueue(false, func() {
+		readPixels = fn
+	})
+}
+
+// CaptureFramebuffer reads back the content of the window's default framebuffer,
+// for example to take screenshots.
+// Call it after rendering a frame, but before swapping buffers.
+//
+// The window's context is made current on the render thread while reading,
+// and the previously current context is restored afterwards.
+// An error wrapping ErrUnsupported is returned if no ReadPixelsFunc has been registered,
+// or if the window has no GL context.
+func (w *Window) CaptureFramebuffer() (image.Image, error) {
+	var img *image.RGBA
+	var err error
+	enqueue(true, func() {
+		if readPixels == nil {
+			err = fmt.Errorf("no ReadPixelsFunc registered: %w", ErrUnsupported)
+			return
+		}
+		if w.Window.GetAttrib(glfw.ClientAPI) == glfw.NoAPI {
+			err = fmt.Errorf("window has no GL context: %w", ErrUnsupported)
+			return
+		}
+		width, height := w.Window.GetFramebufferSize()
+		if width <= 0 || height <= 0 {
+			err = errors.New("framebuffer is empty")
+			return
+		}
+
+		img = image.NewRGBA(image.Rect(0, 0, width, height))
+		w.withCurrentContext(func() {
+			readPixels(0, 0, width, height, img.Pix)
+		})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("capture framebuffer: %w", err)
+	}
+
+	flipRows(img)
+	return img, nil
+}
+
+// withCurrentContext makes the window's context current, calls fn and restores the previously current context.
+// The ContextWatcher is notified about each change. Must be called on the render thread.
+func (w *Window) withCurrentContext(fn func()) {
+	previous := getCurrentContext()
+	if previous == w.Window {
+		fn()
+		return
+	}
+
+	makeContextCurrent(w.Window)
+	contextWatcher.OnMakeCurrent(nil)
+
+	fn()
+
+	if previous == nil {
+		detachCurrentContext()
+		contextWatcher.OnDetach()
+		return
+	}
+	makeContextCurrent(previous)
+	contextWatcher.OnMakeCurrent(nil)
+}
+
+// flipRows mirrors the image vertically, converting between bottom-left and top-left origin.
+func flipRows(img *image.RGBA) {
+	height := img.Rect.Dy()
+	row := make([]byte, img.Stride)
+	for y := 0; y < height/2; y++ {
+		top := img.Pix[y*img.Stride : (y+1)*img.Stride]
+		bottom := img.Pix[(height-1-y)*img.Stride : (height-y)*img.Stride]
+		copy(row, top)
+		copy(top, bottom)
+		copy(bottom, row)
+	}
+}
+
+// FramebufferBitsFunc queries the bit depths of the current context's default framebuffer.
+//
+// With a compatibility profile, it can use glGetIntegerv with GL_RED_BITS etc.
+// With a core profile, glGetFramebufferAttachmentParameteriv must be used on the default framebuffer,
+// for example with GL_BACK_LEFT and GL_FRAMEBUFFER_ATTACHMENT_RED_SIZE.
+type FramebufferBitsFunc func() (red, green, blue, alpha, depth, stencil int)
+
+// framebufferBits is used by Window.FramebufferBits. Only accessed on the render thread.
+var framebufferBits FramebufferBitsFunc
+
+// SetFramebufferBitsFunc registers the function used by Window.FramebufferBits.
+// Like SetReadPixelsFunc, it should be provided by the GL bindings you are using. Passing nil unregisters the function.
+func SetFramebufferBitsFunc(fn FramebufferBitsFunc) {
+	enqueue(false, func() {
+		framebufferBits = fn
+	})
+}
+
+// FramebufferBits returns the actual bit depths of the window's default framebuffer.
+// Since the corresponding window hints are not hard constraints, this allows to verify
+// which precision the driver provided, for example whether a 10-bit framebuffer is available.
+//
+// glfw can't query these values itself, so they are read using the function registered via SetFramebufferBitsFunc,
+// with the window's context made current. If no function is registered or the window has no GL context,
+// all values are DontCare.
+func (w *Window) FramebufferBits() (red, green, blue, alpha, depth, stencil int) {
+	enqueue(true, func() {
+		hasContext := framebufferBits != nil && w.Window.GetAttrib(glfw.ClientAPI) != glfw.NoAPI
+		red, green, blue, alpha, depth, stencil = readFramebufferBits(framebufferBits, hasContext, w.withCurrentContext)
+	})
+	return
+}
+
+// readFramebufferBits calls fn within withContext, or returns DontCare for all values if fn is nil or there is no GL context.
+func readFramebufferBits(fn FramebufferBitsFunc, hasContext bool, withContext func(fn func())) (red, green, blue, alpha, depth, stencil int) {
+	red, green, blue, alpha, depth, stencil = DontCare, DontCare, DontCare, DontCare, DontCare, DontCare
+	if fn == nil || !hasContext {
+		return
+	}
+	withContext(func() {
+		red, green, blue, alpha, depth, stencil = fn()
+	})
+	return
+}
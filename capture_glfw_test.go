@@ -0,0 +1,70 @@
+// +build !js
+
+package glfw
+
+import (
+	"bytes"
+	"image"
+	"testing"
+)
+
+func TestFlipRows(t *testing.T) {
+	tests := []struct {
+		name   string
+		height int
+	}{
+		{"empty", 0},
+		{"single row", 1},
+		{"even", 4},
+		{"odd", 5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			img := image.NewRGBA(image.Rect(0, 0, 2, tt.height))
+			for i := range img.Pix {
+				img.Pix[i] = byte(i / img.Stride) // Each row filled with its index.
+			}
+			flipRows(img)
+			for y := 0; y < tt.height; y++ {
+				want := bytes.Repeat([]byte{byte(tt.height - 1 - y)}, img.Stride)
+				if got := img.Pix[y*img.Stride : (y+1)*img.Stride]; !bytes.Equal(got, want) {
+					t.Errorf("row %d: got %v, want %v", y, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFramebufferBits(t *testing.T) {
+	defer stubEnqueue()()
+	defer SetFramebufferBitsFunc(nil)
+
+	dontCare := [6]int{DontCare, DontCare, DontCare, DontCare, DontCare, DontCare}
+	var r, g, b, a, d, s int
+	r, g, b, a, d, s = new(Window).FramebufferBits()
+	if got := [6]int{r, g, b, a, d, s}; got != dontCare {
+		t.Errorf("unregistered: got %v, want DontCare", got)
+	}
+
+	var current bool // Whether the context is current.
+	withContext := func(fn func()) {
+		current = true
+		fn()
+		current = false
+	}
+	SetFramebufferBitsFunc(func() (red, green, blue, alpha, depth, stencil int) {
+		if !current {
+			t.Error("bits queried without current context")
+		}
+		return 10, 10, 10, 2, 24, 8
+	})
+
+	r, g, b, a, d, s = readFramebufferBits(framebufferBits, true, withContext)
+	if got, want := [6]int{r, g, b, a, d, s}, [6]int{10, 10, 10, 2, 24, 8}; got != want {
+		t.Errorf("registered: got %v, want %v", got, want)
+	}
+	r, g, b, a, d, s = readFramebufferBits(framebufferBits, false, withContext)
+	if got := [6]int{r, g, b, a, d, s}; got != dontCare {
+		t.Errorf("no context: got %v, want DontCare", got)
+	}
+}
@@ -0,0 +1,103 @@
+// +build !js
+
+package glfw
+
+// ChainKeyCallback adds a key callback that runs in addition to the current one, instead of replacing it.
+// Callbacks are invoked in registration order. Calling SetKeyCallback removes the whole chain.
+// A nil fn is ignored, leaving the current callbacks unchanged.
+func (w *Window) ChainKeyCallback(fn KeyCallback) {
+	if fn == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.keyCallback
+	if prev == nil {
+		w.keyCallback = fn
+		return
+	}
+	w.keyCallback = func(w *Window, key Key, scancode int, action Action, mods ModifierKey) {
+		prev(w, key, scancode, action, mods)
+		fn(w, key, scancode, action, mods)
+	}
+}
+
+// ChainCharCallback adds a character callback that runs in addition to the current one, instead of replacing it.
+// Callbacks are invoked in registration order. Calling SetCharCallback removes the whole chain.
+// A nil fn is ignored, leaving the current callbacks unchanged.
+func (w *Window) ChainCharCallback(fn CharCallback) {
+	if fn == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.charCallback
+	if prev == nil {
+		w.charCallback = fn
+		return
+	}
+	w.charCallback = func(w *Window, char rune) {
+		prev(w, char)
+		fn(w, char)
+	}
+}
+
+// ChainMouseButtonCallback adds a mouse button callback that runs in addition to the current one, instead of replacing it.
+// Callbacks are invoked in registration order. Calling SetMouseButtonCallback removes the whole chain.
+// A nil fn is ignored, leaving the current callbacks unchanged.
+func (w *Window) ChainMouseButtonCallback(fn MouseButtonCallback) {
+	if fn == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.mouseButtonCallback
+	if prev == nil {
+		w.mouseButtonCallback = fn
+		return
+	}
+	w.mouseButtonCallback = func(w *Window, button MouseButton, action Action, mods ModifierKey) {
+		prev(w, button, action, mods)
+		fn(w, button, action, mods)
+	}
+}
+
+// ChainScrollCallback adds a scroll callback that runs in addition to the current one, instead of replacing it.
+// Callbacks are invoked in registration order. Calling SetScrollCallback removes the whole chain.
+// A nil fn is ignored, leaving the current callbacks unchanged.
+func (w *Window) ChainScrollCallback(fn ScrollCallback) {
+	if fn == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.scrollCallback
+	if prev == nil {
+		w.scrollCallback = fn
+		return
+	}
+	w.scrollCallback = func(w *Window, xoff float64, yoff float64) {
+		prev(w, xoff, yoff)
+		fn(w, xoff, yoff)
+	}
+}
+
+// ChainCursorPosCallback adds a cursor position callback that runs in addition to the current one, instead of replacing it.
+// Callbacks are invoked in registration order. Calling SetCursorPosCallback removes the whole chain.
+// A nil fn is ignored, leaving the current callbacks unchanged.
+func (w *Window) ChainCursorPosCallback(fn CursorPosCallback) {
+	if fn == nil {
+		return
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	prev := w.cursorPosCallback
+	if prev == nil {
+		w.cursorPosCallback = fn
+		return
+	}
+	w.cursorPosCallback = func(w *Window, xpos float64, ypos float64) {
+		prev(w, xpos, ypos)
+		fn(w, xpos, ypos)
+	}
+}
@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestChainCharCallback(t *testing.T) {
+	var got []string
+	record := func(name string) CharCallback {
+		return func(*Window, rune) { got = append(got, name) }
+	}
+
+	tests := []struct {
+		name  string
+		chain []CharCallback
+		want  []string
+	}{
+		{"none", nil, nil},
+		{"single", []CharCallback{record("a")}, []string{"a"}},
+		{"registration order", []CharCallback{record("a"), record("b"), record("c")}, []string{"a", "b", "c"}},
+		{"nil first", []CharCallback{nil, record("a")}, []string{"a"}},
+		{"nil ignored", []CharCallback{record("a"), nil, record("b")}, []string{"a", "b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got = nil
+			w := new(Window)
+			for _, fn := range tt.chain {
+				w.ChainCharCallback(fn)
+			}
+			if w.charCallback != nil {
+				w.charCallback(w, 'x')
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
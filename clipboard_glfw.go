@@ -0,0 +1,86 @@
+// +build !js
+
+package glfw
+
+/*
+#include <string.h>
+
+typedef void (*errorfun)(int, const char *);
+
+// Provided by the glfw library compiled into go-gl/glfw.
+errorfun glfwSetErrorCallback(errorfun callback);
+
+static errorfun previousErrorCallback;
+static int capturedCode;
+static char capturedDesc[256];
+
+// captureError records the first error reported while capturing.
+static void captureError(int code, const char *desc) {
+	if (capturedCode != 0) {
+		return;
+	}
+	capturedCode = code;
+	strncpy(capturedDesc, desc, sizeof(capturedDesc) - 1);
+	capturedDesc[sizeof(capturedDesc) - 1] = '\0';
+}
+
+static void beginErrorCapture(void) {
+	capturedCode = 0;
+	capturedDesc[0] = '\0';
+	previousErrorCallback = glfwSetErrorCallback(captureError);
+}
+
+static int endErrorCapture(void) {
+	glfwSetErrorCallback(previousErrorCallback);
+	return capturedCode;
+}
+
+// capturedDescription returns the description of the captured error. Static variables can't be accessed from Go.
+static const char *capturedDescription(void) {
+	return capturedDesc;
+}
+*/
+import "C"
+import (
+	"fmt"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// GetClipboardStringErr returns the contents of the clipboard as string.
+//
+// Unlike GetClipboardString, it distinguishes an empty clipboard from contents that can't be converted to a string,
+// like images: in the latter case, an error matching ErrFormatUnavailable is returned.
+func (w *Window) GetClipboardStringErr() (string, error) {
+	var s string
+	var err error
+	enqueue(true, func() {
+		err = captureErrors(func() {
+			s = getClipboardString(w.Window)
+		})
+	})
+	if err != nil {
+		return "", fmt.Errorf("get clipboard: %w", err)
+	}
+	return s, nil
+}
+
+// getClipboardString returns the clipboard contents via a glfw window. Replaced by tests.
+var getClipboardString = (*glfw.Window).GetClipboardString
+
+// captureErrors calls fn and returns the first glfw error reported meanwhile. Replaced by tests.
+var captureErrors = captureGLFWErrors
+
+// captureGLFWErrors implements captureErrors.
+//
+// The error is intercepted before it reaches go-gl/glfw, which would otherwise report it
+// from an unrelated function call later on. Must be called on the render thread.
+func captureGLFWErrors(fn func()) error {
+	C.beginErrorCapture()
+	fn()
+	code := C.endErrorCapture()
+	if code == 0 {
+		return nil
+	}
+	return &Error{Code: glfw.ErrorCode(code), Desc: C.GoString(C.capturedDescription())}
+}
@@ -0,0 +1,52 @@
+// +build !js
+
+package glfw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestCaptureGLFWErrors(t *testing.T) {
+	if err := captureGLFWErrors(func() {}); err != nil {
+		t.Errorf("got error %v without glfw errors", err)
+	}
+	// glfw reports an error for most functions if it isn't initialized, which is the case in tests.
+	err := captureGLFWErrors(func() { glfw.GetTime() })
+	var glfwErr *Error
+	if !errors.As(err, &glfwErr) || !errors.Is(err, ErrNotInitialized) || glfwErr.Desc == "" {
+		t.Errorf("got error %#v, want a described not initialized error", err)
+	}
+}
+
+func TestGetClipboardStringErr(t *testing.T) {
+	defer stubEnqueue()()
+	previousGet, previousCapture := getClipboardString, captureErrors
+	defer func() { getClipboardString, captureErrors = previousGet, previousCapture }()
+
+	var code glfw.ErrorCode // Reported while reading the clipboard, if not zero.
+	contents := ""
+	getClipboardString = func(*glfw.Window) string { return contents }
+	captureErrors = func(fn func()) error {
+		fn()
+		if code == 0 {
+			return nil
+		}
+		return &Error{Code: code, Desc: "clipboard contents unavailable"}
+	}
+
+	w := new(Window)
+	if s, err := w.GetClipboardStringErr(); s != "" || err != nil {
+		t.Errorf("empty clipboard: got %q and error %v", s, err)
+	}
+	contents = "text"
+	if s, err := w.GetClipboardStringErr(); s != "text" || err != nil {
+		t.Errorf("text: got %q and error %v", s, err)
+	}
+	contents, code = "", glfw.FormatUnavailable // Like an image on the clipboard.
+	if s, err := w.GetClipboardStringErr(); s != "" || !errors.Is(err, ErrFormatUnavailable) {
+		t.Errorf("image: got %q and error %v, want ErrFormatUnavailable", s, err)
+	}
+}
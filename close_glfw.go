@@ -0,0 +1,36 @@
+// +build !js
+
+package glfw
+
+import "context"
+
+// SetShouldClose sets the value of the close flag of the window.
+// This can be used to override the user's attempt to close the window, or to signal that it should be closed.
+//
+// Unlike most functions, it can be called from any goroutine.
+func (w *Window) SetShouldClose(value bool) {
+	w.Window.SetShouldClose(value)
+	if value {
+		w.signalClosed()
+	}
+}
+
+// WaitForClose blocks until the window is flagged for closing, either by the user or via SetShouldClose,
+// or until ctx is done. In the latter case, ctx.Err() is returned.
+//
+// Attempts to close the window that are vetoed by the close callback, by resetting the close flag, are ignored.
+// Once the window has been flagged for closing, WaitForClose returns immediately, even if the flag is reset later on.
+func (w *Window) WaitForClose(ctx context.Context) error {
+	select {
+	case <-w.closed:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (w *Window) signalClosed() {
+	w.closedOnce.Do(func() {
+		close(w.closed)
+	})
+}
@@ -0,0 +1,45 @@
+// +build !js
+
+package glfw
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestWaitForClose(t *testing.T) {
+	w := &Window{closed: make(chan struct{})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := w.WaitForClose(ctx); err != context.Canceled {
+		t.Errorf("cancelled: got %v, want %v", err, context.Canceled)
+	}
+
+	ctx, cancel = context.WithTimeout(context.Background(), time.Millisecond)
+	defer cancel()
+	if err := w.WaitForClose(ctx); err != context.DeadlineExceeded {
+		t.Errorf("timed out: got %v, want %v", err, context.DeadlineExceeded)
+	}
+
+	done := make(chan error)
+	go func() {
+		done <- w.WaitForClose(context.Background())
+	}()
+	w.signalClosed()
+	w.signalClosed() // Closing twice doesn't panic.
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Errorf("closed: got %v, want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WaitForClose didn't return after the window was flagged for closing")
+	}
+
+	// Returns immediately once the window was flagged for closing.
+	if err := w.WaitForClose(context.Background()); err != nil {
+		t.Errorf("already closed: got %v, want nil", err)
+	}
+}
@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+// coalescedCursorPos holds the latest cursor position for the coalesced cursor position callback.
+type coalescedCursorPos struct {
+	callback CursorPosCallback // Nil if disabled.
+	pending  bool              // Whether the cursor moved since the last call.
+	x, y     float64
+}
+
+// SetCoalescedCursorPosCallback sets a cursor position callback that is called at most once per batch of processed events,
+// like a call to PollEvents or WaitEvents, with the latest cursor position.
+//
+// High polling rate mice report hundreds of positions per frame; this keeps expensive work like hit-testing
+// to a single call per frame. The regular cursor position callback keeps receiving every position,
+// and movements are still accumulated for MouseDelta, so nothing is lost while the cursor is disabled.
+// The callback is called on the render thread. Passing nil removes it.
+func (w *Window) SetCoalescedCursorPosCallback(cbfun CursorPosCallback) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.coalescedCursor = coalescedCursorPos{callback: cbfun}
+}
+
+// flushCoalescedEvents delivers coalesced events of all windows.
+// Must be called on the render thread after processing events.
+func flushCoalescedEvents() {
+	for _, w := range Windows() {
+		w.flushCoalescedCursorPos()
+	}
+}
+
+func (w *Window) flushCoalescedCursorPos() {
+	w.mu.Lock()
+	c := w.coalescedCursor
+	w.coalescedCursor.pending = false
+	w.mu.Unlock()
+
+	if c.pending && c.callback != nil {
+		c.callback(w, c.x, c.y)
+	}
+}
@@ -0,0 +1,45 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCoalescedCursorPos(t *testing.T) {
+	w := new(Window)
+	var coalesced [][2]float64
+	w.SetCoalescedCursorPosCallback(func(_ *Window, x, y float64) {
+		coalesced = append(coalesced, [2]float64{x, y})
+	})
+	var moves int
+	w.SetCursorPosCallback(func(*Window, float64, float64) { moves++ })
+
+	w.dispatch(CursorPosEvent{X: 1, Y: 1})
+	w.dispatch(CursorPosEvent{X: 2, Y: 3})
+	w.dispatch(CursorPosEvent{X: 4, Y: 5})
+	w.flushCoalescedCursorPos()
+	w.flushCoalescedCursorPos() // No movement since.
+	w.dispatch(KeyEvent{Key: KeyA, Action: Press})
+	w.flushCoalescedCursorPos()
+	w.dispatch(CursorPosEvent{X: 6, Y: 7})
+	w.flushCoalescedCursorPos()
+
+	if want := [][2]float64{{4, 5}, {6, 7}}; !reflect.DeepEqual(coalesced, want) {
+		t.Errorf("got coalesced positions %v, want %v", coalesced, want)
+	}
+	if moves != 4 {
+		t.Errorf("cursor position callback got %d positions, want 4", moves)
+	}
+	if dx, dy := w.mouseDelta.dx, w.mouseDelta.dy; dx != 5 || dy != 6 {
+		t.Errorf("accumulated movement (%v, %v), want (5, 6)", dx, dy)
+	}
+
+	w.SetCoalescedCursorPosCallback(nil)
+	w.dispatch(CursorPosEvent{X: 8, Y: 9})
+	w.flushCoalescedCursorPos()
+	if len(coalesced) != 2 {
+		t.Errorf("removed callback was called")
+	}
+}
@@ -3,14 +3,14 @@
 package glfw
 
 import (
-	"errors"
+	"fmt"
 
 	"github.com/gopherjs/gopherjs/js"
 )
 
 func newContext(canvas *js.Object, ca *contextAttributes) (context *js.Object, err error) {
 	if js.Global.Get("WebGLRenderingContext") == js.Undefined {
-		return nil, errors.New("Your browser doesn't appear to support WebGL.")
+		return nil, fmt.Errorf("%w: your browser doesn't appear to support WebGL", ErrUnsupported)
 	}
 
 	attrs := map[string]bool{
@@ -29,7 +29,7 @@ func newContext(canvas *js.Object, ca *contextAttributes) (context *js.Object, e
 	} else if gl := canvas.Call("getContext", "experimental-webgl", attrs); gl != nil {
 		return gl, nil
 	} else {
-		return nil, errors.New("Creating a WebGL context has failed.")
+		return nil, fmt.Errorf("%w: creating a WebGL context has failed", ErrContextCreationFailed)
 	}
 }
 
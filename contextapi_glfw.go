@@ -0,0 +1,51 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// AvailableContextAPIs returns the values of the ContextCreationAPI hint that work on this system,
+// out of NativeContextAPI, EGLContextAPI and OSMesaContextAPI.
+//
+// Each API is probed by creating and destroying a hidden window using the current hints,
+// so this is expensive and should be called once at startup.
+// The Visible and ContextCreationAPI hints are restored afterwards.
+func AvailableContextAPIs() []int {
+	var available []int
+	enqueue(true, func() {
+		if !initialized {
+			return
+		}
+		setHint := func(target Hint, value int) {
+			windowHint(glfw.Hint(target), value)
+		}
+		available = probeContextAPIs(probeWindow, setHint)
+	})
+	return available
+}
+
+// probeContextAPIs applies each context creation API using setHint and returns the ones for which probe succeeds.
+// The hints are restored afterwards. Must be called on the render thread.
+func probeContextAPIs(probe func() bool, setHint func(Hint, int)) []int {
+	var available []int
+	setHint(Visible, glfw.False)
+	for _, api := range []int{NativeContextAPI, EGLContextAPI, OSMesaContextAPI} {
+		setHint(ContextCreationAPI, api)
+		if probe() {
+			available = append(available, api)
+		}
+	}
+	setHint(Visible, hintValue(Visible, glfw.True))
+	setHint(ContextCreationAPI, hintValue(ContextCreationAPI, NativeContextAPI))
+	return available
+}
+
+// probeWindow reports whether a window can be created with the current hints. Must be called on the render thread.
+func probeWindow() bool {
+	w, err := glfw.CreateWindow(1, 1, "", nil, nil)
+	if err != nil || w == nil {
+		return false
+	}
+	w.Destroy()
+	return true
+}
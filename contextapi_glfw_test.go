@@ -0,0 +1,37 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestProbeContextAPIs(t *testing.T) {
+	var enqueued int
+	defer recordHints(&enqueued)()
+	WindowHint(ContextCreationAPI, OSMesaContextAPI) // Set by the user before, and restored afterwards.
+
+	hints := make(map[Hint]int)
+	var probed []int
+	probe := func() bool {
+		if hints[Visible] != glfw.False {
+			t.Error("probe window is visible")
+		}
+		probed = append(probed, hints[ContextCreationAPI])
+		return hints[ContextCreationAPI] == EGLContextAPI
+	}
+	available := probeContextAPIs(probe, func(target Hint, value int) { hints[target] = value })
+
+	if want := []int{EGLContextAPI}; !reflect.DeepEqual(available, want) {
+		t.Errorf("got available APIs %v, want %v", available, want)
+	}
+	if want := []int{NativeContextAPI, EGLContextAPI, OSMesaContextAPI}; !reflect.DeepEqual(probed, want) {
+		t.Errorf("probed %v, want %v", probed, want)
+	}
+	if want := map[Hint]int{Visible: glfw.True, ContextCreationAPI: OSMesaContextAPI}; !reflect.DeepEqual(hints, want) {
+		t.Errorf("hints afterwards: got %v, want %v", hints, want)
+	}
+}
@@ -0,0 +1,37 @@
+// +build !js
+
+package glfw
+
+// framebufferRatio tracks the ratio of framebuffer size to window size.
+type framebufferRatio struct {
+	width, height     int // Window size in screen coordinates.
+	fbWidth, fbHeight int // Framebuffer size in pixels.
+	x, y              float64
+}
+
+// update recomputes the ratio. The previous ratio is kept while either size is zero, like for iconified windows.
+func (r *framebufferRatio) update() {
+	if r.width > 0 && r.height > 0 && r.fbWidth > 0 && r.fbHeight > 0 {
+		r.x = float64(r.fbWidth) / float64(r.width)
+		r.y = float64(r.fbHeight) / float64(r.height)
+	}
+}
+
+// ScreenToFramebuffer converts a position within the content area from screen coordinates,
+// as reported by cursor events, to framebuffer pixels.
+//
+// The conversion uses the ratio of framebuffer size to window size, which is tracked by the size callbacks.
+// This is more robust than the content scale, which differs from the ratio on some platforms.
+func (w *Window) ScreenToFramebuffer(x, y float64) (float64, float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return x * w.fbRatio.x, y * w.fbRatio.y
+}
+
+// FramebufferToScreen converts a position within the content area from framebuffer pixels to screen coordinates.
+// It is the inverse of ScreenToFramebuffer.
+func (w *Window) FramebufferToScreen(x, y float64) (float64, float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return x / w.fbRatio.x, y / w.fbRatio.y
+}
@@ -0,0 +1,40 @@
+// +build !js
+
+package glfw
+
+import "testing"
+
+func TestFramebufferRatioUpdate(t *testing.T) {
+	tests := []struct {
+		name              string
+		width, height     int
+		fbWidth, fbHeight int
+		wantX, wantY      float64
+	}{
+		{"unscaled", 800, 600, 800, 600, 1, 1},
+		{"retina", 800, 600, 1600, 1200, 2, 2},
+		{"anisotropic", 800, 600, 1200, 600, 1.5, 1},
+		{"iconified keeps previous", 0, 0, 0, 0, 3, 3},
+		{"zero framebuffer keeps previous", 800, 600, 0, 0, 3, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := framebufferRatio{width: tt.width, height: tt.height, fbWidth: tt.fbWidth, fbHeight: tt.fbHeight, x: 3, y: 3}
+			r.update()
+			if r.x != tt.wantX || r.y != tt.wantY {
+				t.Errorf("got (%v, %v), want (%v, %v)", r.x, r.y, tt.wantX, tt.wantY)
+			}
+		})
+	}
+}
+
+func TestScreenToFramebuffer(t *testing.T) {
+	w := new(Window)
+	w.fbRatio = framebufferRatio{x: 2, y: 1.5}
+	if x, y := w.ScreenToFramebuffer(10, 10); x != 20 || y != 15 {
+		t.Errorf("ScreenToFramebuffer: got (%v, %v), want (20, 15)", x, y)
+	}
+	if x, y := w.FramebufferToScreen(20, 15); x != 10 || y != 10 {
+		t.Errorf("FramebufferToScreen: got (%v, %v), want (10, 10)", x, y)
+	}
+}
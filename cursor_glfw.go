@@ -0,0 +1,200 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"sync"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// CursorShape is the shape of a standard cursor.
+type CursorShape glfw.StandardCursor
+
+const (
+	ArrowCursor     = CursorShape(glfw.ArrowCursor)
+	IBeamCursor     = CursorShape(glfw.IBeamCursor)
+	CrosshairCursor = CursorShape(glfw.CrosshairCursor)
+	HandCursor      = CursorShape(glfw.HandCursor)
+	HResizeCursor   = CursorShape(glfw.HResizeCursor)
+	VResizeCursor   = CursorShape(glfw.VResizeCursor)
+)
+
+// Cursor represents a cursor.
+type Cursor struct {
+	*glfw.Cursor
+
+	shape      CursorShape
+	cached     bool // Whether this is a shared standard cursor.
+	refs       int  // Number of references held by the creator, StandardCursor callers and windows using the cursor. Guarded by cursorCacheMu.
+	generation int  // Value of cursorGeneration when the cursor was created.
+}
+
+var (
+	cursorCacheMu    sync.Mutex
+	cursorCache      = make(map[CursorShape]*Cursor)
+	cursorGeneration int // Incremented by Terminate, which destroys all cursors. Guarded by cursorCacheMu.
+)
+
+// currentCursorGeneration returns the generation of cursors created now.
+func currentCursorGeneration() int {
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	return cursorGeneration
+}
+
+// CreateCursor creates a new custom cursor image that can be set for a window with SetCursor.
+//
+// The cursor hotspot is specified in pixels, relative to the upper-left corner of the cursor image.
+func CreateCursor(img image.Image, xhot, yhot int) *Cursor {
+	var c *glfw.Cursor
+	enqueue(true, func() {
+		c = glfw.CreateCursor(img, xhot, yhot)
+	})
+	return &Cursor{Cursor: c, refs: 1, generation: currentCursorGeneration()}
+}
+
+// CreateStandardCursor returns a cursor with a standard shape, that can be set for a window with SetCursor.
+//
+// Standard cursors are shared, see StandardCursor.
+func CreateStandardCursor(shape CursorShape) *Cursor {
+	return StandardCursor(shape)
+}
+
+// StandardCursor returns the shared cursor with the given standard shape.
+//
+// Repeated calls for the same shape return the same cursor object instead of creating new ones.
+// Each call acquires a reference that must be released with Destroy;
+// the underlying cursor is destroyed when the last reference is released.
+func StandardCursor(shape CursorShape) *Cursor {
+	if c := acquireCachedCursor(shape); c != nil {
+		return c
+	}
+
+	// The cursor is created without holding cursorCacheMu, so that other goroutines aren't blocked by the render thread.
+	var created *glfw.Cursor
+	generation := currentCursorGeneration()
+	enqueue(true, func() {
+		created = glfw.CreateStandardCursor(glfw.StandardCursor(shape))
+	})
+
+	cursorCacheMu.Lock()
+	if c, ok := cursorCache[shape]; ok { // Created concurrently by another caller.
+		c.refs++
+		cursorCacheMu.Unlock()
+		if created != nil {
+			enqueue(false, created.Destroy)
+		}
+		return c
+	}
+	c := &Cursor{
+		Cursor:     created,
+		shape:      shape,
+		cached:     true,
+		refs:       1,
+		generation: generation,
+	}
+	cursorCache[shape] = c
+	cursorCacheMu.Unlock()
+	return c
+}
+
+// acquireCachedCursor returns the shared cursor with the given shape and acquires a reference, or nil if it isn't cached.
+func acquireCachedCursor(shape CursorShape) *Cursor {
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	c, ok := cursorCache[shape]
+	if !ok {
+		return nil
+	}
+	c.refs++
+	return c
+}
+
+// Destroy destroys a cursor previously created with CreateCursor.
+// Any remaining cursors will be destroyed by Terminate.
+//
+// Destroy releases the caller's reference to the cursor.
+// Windows using the cursor hold references of their own, which are released when a different cursor is set
+// or the window is destroyed. The cursor is only destroyed once no references are left,
+// so it is safe to destroy a cursor right after setting it, leaving the cleanup to the window.
+// For shared standard cursors, a single reference is released as well.
+// Destroying a cursor more often than references were acquired, or after Terminate, has no effect.
+func (c *Cursor) Destroy() {
+	cursorCacheMu.Lock()
+	if c.refs <= 0 || c.generation != cursorGeneration {
+		cursorCacheMu.Unlock()
+		return
+	}
+	c.refs--
+	if c.refs > 0 {
+		cursorCacheMu.Unlock()
+		return
+	}
+	if c.cached {
+		delete(cursorCache, c.shape)
+	}
+	cursorCacheMu.Unlock()
+
+	if c.Cursor != nil { // Creation failed.
+		enqueue(false, c.Cursor.Destroy)
+	}
+}
+
+// native returns the glfw cursor, or nil if it was destroyed by Terminate.
+func (c *Cursor) native() *glfw.Cursor {
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	if c.generation != cursorGeneration {
+		return nil
+	}
+	return c.Cursor
+}
+
+// acquire adds a reference to the cursor.
+func (c *Cursor) acquire() {
+	cursorCacheMu.Lock()
+	defer cursorCacheMu.Unlock()
+	c.refs++
+}
+
+// SetCursor sets the cursor image to be used when the cursor is over the client area
+// of the specified window. The set cursor will only be visible when the cursor mode of the
+// window is CursorNormal.
+//
+// On some platforms, the set cursor may not be visible unless the window also has input focus.
+// Passing nil restores the default arrow cursor.
+//
+// The window holds a reference to the cursor until a different cursor is set or the window is destroyed.
+func (w *Window) SetCursor(c *Cursor) {
+	var cursor *glfw.Cursor
+	if c != nil {
+		cursor = c.native()
+		c.acquire()
+	}
+
+	w.mu.Lock()
+	previous := w.cursor
+	w.cursor = c
+	w.mu.Unlock()
+
+	enqueue(false, func() {
+		w.Window.SetCursor(cursor)
+	})
+	if previous != nil {
+		previous.Destroy()
+	}
+}
+
+// releaseCursor releases the window's reference to its cursor, after the window was destroyed.
+func (w *Window) releaseCursor() {
+	w.mu.Lock()
+	c := w.cursor
+	w.cursor = nil
+	w.mu.Unlock()
+
+	if c != nil {
+		c.Destroy()
+	}
+}
@@ -0,0 +1,94 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestCursorDestroy(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	var destroyed int
+	enqueue = func(_ bool, fn func()) { destroyed++ } // Never call into glfw.
+
+	c := &Cursor{Cursor: new(glfw.Cursor), refs: 1, generation: currentCursorGeneration()}
+	c.acquire() // Held by a window.
+
+	c.Destroy()
+	if c.refs != 1 || destroyed != 0 {
+		t.Fatalf("after first destroy: refs %d, destroyed %d, want 1 and 0", c.refs, destroyed)
+	}
+	c.Destroy()
+	if c.refs != 0 || destroyed != 1 {
+		t.Fatalf("after last destroy: refs %d, destroyed %d, want 0 and 1", c.refs, destroyed)
+	}
+	c.Destroy()
+	if c.refs != 0 || destroyed != 1 {
+		t.Errorf("after redundant destroy: refs %d, destroyed %d, want 0 and 1", c.refs, destroyed)
+	}
+}
+
+func TestCursorDestroyCached(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	enqueue = func(_ bool, fn func()) {}
+
+	c := &Cursor{Cursor: new(glfw.Cursor), shape: HandCursor, cached: true, refs: 1, generation: currentCursorGeneration()}
+	cursorCacheMu.Lock()
+	cursorCache[HandCursor] = c
+	cursorCacheMu.Unlock()
+
+	c.Destroy()
+	cursorCacheMu.Lock()
+	_, ok := cursorCache[HandCursor]
+	cursorCacheMu.Unlock()
+	if ok {
+		t.Error("destroyed standard cursor is still cached")
+	}
+}
+
+func TestCursorDestroyAfterTerminate(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	var destroyed int
+	enqueue = func(_ bool, fn func()) { destroyed++ }
+
+	c := &Cursor{Cursor: new(glfw.Cursor), refs: 1, generation: currentCursorGeneration()}
+	cursorCacheMu.Lock()
+	cursorGeneration++ // As done by terminate.
+	cursorCacheMu.Unlock()
+
+	if c.native() != nil {
+		t.Error("cursor of a previous generation is still usable")
+	}
+	c.Destroy()
+	if destroyed != 0 {
+		t.Errorf("cursor destroyed by Terminate was destroyed %d more times", destroyed)
+	}
+}
+
+func TestWindowCursorReference(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	var enqueued int
+	enqueue = func(_ bool, fn func()) { enqueued++ } // Never call into glfw.
+
+	c := &Cursor{Cursor: new(glfw.Cursor), refs: 1, generation: currentCursorGeneration()}
+	w := new(Window)
+	w.SetCursor(c)
+	if c.refs != 2 {
+		t.Fatalf("after setting the cursor: refs %d, want 2", c.refs)
+	}
+	c.Destroy() // Released by the creator, still used by the window.
+	if c.refs != 1 || enqueued != 1 {
+		t.Fatalf("after releasing the creator's reference: refs %d and %d commands, want 1 and 1", c.refs, enqueued)
+	}
+
+	w.Destroy()
+	if c.refs != 0 || enqueued != 3 {
+		t.Errorf("after destroying the window: refs %d and %d commands, want 0 and 3 (destroying window and cursor)", c.refs, enqueued)
+	}
+}
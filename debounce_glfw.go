@@ -0,0 +1,62 @@
+// +build !js
+
+package glfw
+
+import "time"
+
+// SetKeyDebounce drops a key press if the same key was pressed less than d ago,
+// along with the release belonging to the dropped press. Zero or negative values disable debouncing, which is the default.
+//
+// It is intended for keyboards and remote input setups that emit spurious duplicate presses.
+// Repeat events reported by glfw are not affected, and neither are presses of different keys, so fast typing is preserved.
+// Dropped events don't reach event filters, callbacks, input channels or the tracked input state.
+// The scancode callback and input recording still observe them, as they receive events as reported by the platform.
+func (w *Window) SetKeyDebounce(d time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.keyDebounce = keyDebounce{interval: d}
+}
+
+// keyDebounce detects duplicate key presses.
+type keyDebounce struct {
+	interval time.Duration
+	pressed  map[Key]time.Time // Time of the last delivered press of each key.
+	down     map[Key]bool      // Keys whose last delivered event was a press.
+	dropped  map[Key]bool      // Keys whose last press was dropped while released, so that their repeats and release are dropped as well.
+}
+
+// drop reports whether the key event is a duplicate, and records it otherwise.
+//
+// A duplicate press arriving while the key is still down, without a release in between, is dropped on its own,
+// so that the release of the delivered press isn't swallowed and the key doesn't stay down.
+func (d *keyDebounce) drop(ev KeyEvent, now time.Time) bool {
+	if d.interval <= 0 {
+		return false
+	}
+	if d.down == nil {
+		d.pressed = make(map[Key]time.Time)
+		d.down = make(map[Key]bool)
+		d.dropped = make(map[Key]bool)
+	}
+	switch ev.Action {
+	case Press:
+		if last, ok := d.pressed[ev.Key]; ok && now.Sub(last) < d.interval {
+			if !d.down[ev.Key] {
+				d.dropped[ev.Key] = true
+			}
+			return true
+		}
+		d.pressed[ev.Key] = now
+		d.down[ev.Key] = true
+		delete(d.dropped, ev.Key)
+	case Repeat:
+		return d.dropped[ev.Key] // Repeats of a dropped press would mark the key as down.
+	case Release:
+		if d.dropped[ev.Key] {
+			delete(d.dropped, ev.Key)
+			return true
+		}
+		delete(d.down, ev.Key)
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestKeyDebounceDrop(t *testing.T) {
+	type step struct {
+		key    Key
+		action Action
+		at     time.Duration // Since the start of the test.
+	}
+	tests := []struct {
+		name     string
+		interval time.Duration
+		steps    []step
+		want     []bool // Whether each event is dropped.
+	}{
+		{"disabled", 0, []step{{KeyA, Press, 0}, {KeyA, Release, 1}, {KeyA, Press, 2}}, []bool{false, false, false}},
+		{"duplicate press", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyA, Release, 10 * time.Millisecond},
+			{KeyA, Press, 20 * time.Millisecond}, {KeyA, Release, 30 * time.Millisecond},
+		}, []bool{false, false, true, true}},
+		{"press after interval", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyA, Release, 10 * time.Millisecond},
+			{KeyA, Press, 60 * time.Millisecond}, {KeyA, Release, 70 * time.Millisecond},
+		}, []bool{false, false, false, false}},
+		{"different keys", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyB, Press, 10 * time.Millisecond},
+			{KeyA, Release, 20 * time.Millisecond}, {KeyB, Release, 30 * time.Millisecond},
+		}, []bool{false, false, false, false}},
+		{"repeats of dropped press", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyA, Release, 10 * time.Millisecond},
+			{KeyA, Press, 20 * time.Millisecond}, {KeyA, Repeat, 30 * time.Millisecond}, {KeyA, Release, 40 * time.Millisecond},
+		}, []bool{false, false, true, true, true}},
+		{"duplicate press while down", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyA, Press, 10 * time.Millisecond},
+			{KeyA, Repeat, 20 * time.Millisecond}, {KeyA, Release, 30 * time.Millisecond},
+		}, []bool{false, true, false, false}},
+		{"repeats unaffected", 50 * time.Millisecond, []step{
+			{KeyA, Press, 0}, {KeyA, Repeat, 10 * time.Millisecond}, {KeyA, Release, 20 * time.Millisecond},
+		}, []bool{false, false, false}},
+	}
+	start := time.Now()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := keyDebounce{interval: tt.interval}
+			var got []bool
+			for _, s := range tt.steps {
+				got = append(got, d.drop(KeyEvent{Key: s.key, Action: s.action}, start.Add(s.at)))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if d.down[KeyA] || d.down[KeyB] {
+				t.Error("key is still down after its release")
+			}
+		})
+	}
+}
@@ -4,9 +4,15 @@ package glfw
 
 import "C"
 import (
+	"fmt"
+	"image"
 	"io"
+	"math"
 	"os"
+	"path/filepath"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/go-gl/glfw/v3.3/glfw"
 )
@@ -14,10 +20,47 @@ import (
 var enqueue func(blocking bool, fn func())
 var contextWatcher ContextWatcher
 
+// RenderThread executes glfw commands on a single OS thread.
+//
+// Commands must be executed in the order they were enqueued, regardless of whether they are blocking.
+// Flush and other synchronization in this package rely on this ordering.
 type RenderThread interface {
+	// Enqueue schedules fn for execution. If blocking is true, it waits until fn has returned.
 	Enqueue(blocking bool, fn func())
 }
 
+// PriorityRenderThread is an optional extension of RenderThread.
+//
+// If implemented, event processing is scheduled with priority, so that input stays responsive
+// while the render thread is busy with rendering commands.
+type PriorityRenderThread interface {
+	RenderThread
+
+	// EnqueuePriority schedules fn for execution before all regular commands that are still pending.
+	// It does not wait for fn to be executed.
+	EnqueuePriority(fn func())
+}
+
+var enqueuePriority func(fn func())
+
+// enqueueEvents schedules event processing, with priority if supported by the render thread.
+func enqueueEvents(blocking bool, fn func()) {
+	if enqueuePriority == nil {
+		enqueue(blocking, fn)
+		return
+	}
+	if !blocking {
+		enqueuePriority(fn)
+		return
+	}
+	done := make(chan struct{})
+	enqueuePriority(func() {
+		defer close(done)
+		fn()
+	})
+	<-done
+}
+
 // Init initializes the library.
 //
 // Expects a render thread to execute commands.
@@ -26,19 +69,112 @@ type RenderThread interface {
 func Init(renderThread RenderThread, cw ContextWatcher) error {
 	contextWatcher = cw
 	enqueue = renderThread.Enqueue
+	enqueuePriority = nil
+	if p, ok := renderThread.(PriorityRenderThread); ok {
+		enqueuePriority = p.EnqueuePriority
+	}
 
 	var err error
 	enqueue(true, func() {
-		err = glfw.Init()
+		recordRenderThread()
+		if err = glfw.Init(); err != nil {
+			err = wrapError(err)
+			return
+		}
+		initialized = true
+		installMonitorCallback()
 	})
 	return err
 }
 
+// initialized is set between Init and Terminate. Only accessed on the render thread.
+var initialized bool
+
+// InitVulkan initializes the library for applications that don't use OpenGL, like Vulkan renderers.
+//
+// Expects a render thread to execute commands.
+// Windows created afterwards don't have a GL context, as the ClientAPI hint defaults to NoAPI,
+// even after calling DefaultWindowHints.
+// GL-specific functions like MakeContextCurrent, DetachCurrentContext, SwapInterval and SwapBuffers are unsupported.
+func InitVulkan(renderThread RenderThread) error {
+	noAPI = true
+	if err := Init(renderThread, noopContextWatcher{}); err != nil {
+		return err
+	}
+	enqueue(false, func() {
+		windowHint(glfw.ClientAPI, glfw.NoAPI)
+	})
+	return nil
+}
+
+// noAPI is set if windows should be created without GL context by default.
+var noAPI bool
+
+// noopContextWatcher is used if there is no GL context to watch.
+type noopContextWatcher struct{}
+
+func (noopContextWatcher) OnMakeCurrent(context interface{}) {}
+func (noopContextWatcher) OnDetach()                         {}
+
 // Terminate destroys all remaining windows, frees any allocated resources and de-initializes the library.
 func Terminate() {
+	enqueue(false, terminate)
+}
+
+// TerminateAndWait is like Terminate, but blocks until the library has been de-initialized.
+//
+// All commands enqueued before the call, like non-blocking Destroy calls, are guaranteed
+// to have been executed before glfw is terminated, as the render thread executes commands in order.
+// Use it before exiting the program, so that termination isn't cut short.
+func TerminateAndWait() {
+	Flush()
+	enqueue(true, terminate)
+}
+
+func terminate() {
+	initialized = false
+	glfw.Terminate()
+	workareas = nil
+
+	// Terminate destroyed all remaining windows and cursors.
+	windowsMu.Lock()
+	windows = nil
+	windowsMu.Unlock()
+
+	cursorCacheMu.Lock()
+	cursorCache = make(map[CursorShape]*Cursor)
+	cursorGeneration++ // Invalidates existing cursors.
+	cursorCacheMu.Unlock()
+}
+
+// Flush blocks until all previously enqueued commands have been executed by the render thread.
+//
+// Setters don't wait for their changes to be applied. Flush acts as a barrier,
+// for example to ensure all window changes took effect before taking a screenshot.
+func Flush() {
+	enqueue(true, func() {})
+}
+
+// RenderThreadAlive reports whether the render thread executes commands within the given timeout.
+//
+// It is intended for watchdogs detecting a stalled render thread, for example due to a deadlock in driver code.
+// The probe is enqueued non-blocking, so no goroutine is left behind if the render thread does not respond;
+// the probe is simply executed later on, once the render thread recovers.
+func RenderThreadAlive(timeout time.Duration) bool {
+	done := make(chan struct{})
 	enqueue(false, func() {
-		glfw.Terminate()
+		close(done)
 	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-done:
+		return true
+	case <-timer.C:
+		return false
+	}
 }
 
 // CreateWindow creates a window and its associated context. Most of the options
@@ -54,43 +190,308 @@ func CreateWindow(width, height int, title string, monitor *Monitor, share *Wind
 		s = share.Window
 	}
 
+	if enqueue == nil {
+		return nil, fmt.Errorf("create window: %w", ErrNotInitialized)
+	}
+
+	var err error
+	var window *Window
+	enqueue(true, func() {
+		window, err = createWindow(width, height, title, m, s)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create window: %w", err)
+	}
+	return window, nil
+}
+
+// createWindow creates a window using the current hints. Replaced by tests.
+var createWindow = newWindow
+
+// newWindow creates a window using the current hints.
+// Must be called on the render thread.
+func newWindow(width, height int, title string, monitor *glfw.Monitor, share *glfw.Window) (*Window, error) {
+	if !initialized {
+		return nil, ErrNotInitialized
+	}
+	// Errors are captured, since go-gl/glfw panics on errors other than APIUnavailable and VersionUnavailable,
+	// like FormatUnavailable for unsupported framebuffer formats.
 	var w *glfw.Window
 	var err error
+	if cerr := captureErrors(func() {
+		w, err = glfw.CreateWindow(width, height, title, monitor, share)
+	}); cerr != nil {
+		return nil, cerr
+	}
+	if err != nil {
+		return nil, wrapError(err)
+	}
+	if w == nil { // Platform errors are only logged by glfw.
+		return nil, ErrContextCreationFailed
+	}
+	window := &Window{Window: w, hintFallback: -1, closed: make(chan struct{}), baseTitle: title}
+	window.installCallbacks()
+	registerWindow(window)
+	return window, nil
+}
+
+// WindowOptions configures a window created by CreateWindowWithOptions.
+type WindowOptions struct {
+	Monitor *Monitor     // Monitor for full screen mode, or nil for windowed mode.
+	Share   *Window      // Window whose context to share resources with, or nil.
+	Hints   map[Hint]int // Hints applied on top of the current hints, only for this window.
+}
+
+// CreateWindowWithOptions is like CreateWindow, but applies the given hints only for the created window.
+//
+// Setting the hints, creating the window and restoring the previous hints happens within a single command
+// on the render thread, so window creation is serialized: windows created concurrently from multiple goroutines
+// each get exactly their own hints, which is not the case when calling WindowHint before CreateWindow.
+// Hints set via WindowHint are left unchanged.
+func CreateWindowWithOptions(width, height int, title string, opts WindowOptions) (*Window, error) {
+	var m *glfw.Monitor
+	if opts.Monitor != nil {
+		m = opts.Monitor.Monitor
+	}
+	var s *glfw.Window
+	if opts.Share != nil {
+		s = opts.Share.Window
+	}
+
+	if enqueue == nil {
+		return nil, fmt.Errorf("create window: %w", ErrNotInitialized)
+	}
+
+	var err error
+	var window *Window
 	enqueue(true, func() {
-		w, err = glfw.CreateWindow(width, height, title, m, s)
+		for target, value := range opts.Hints {
+			if target, ok := resolveHint(target); ok {
+				windowHint(glfw.Hint(target), value)
+			}
+		}
+		window, err = createWindow(width, height, title, m, s)
+		if len(opts.Hints) > 0 {
+			restoreHints()
+		}
 	})
 	if err != nil {
-		return nil, err
+		return nil, fmt.Errorf("create window: %w", err)
 	}
+	return window, nil
+}
 
-	window := &Window{Window: w}
+// CreateUtilityWindow creates a hidden, undecorated 1x1 window whose context is shared with the given window.
+// It is intended as context host for loading GL resources in the background, like uploading textures,
+// while the shared window keeps rendering.
+//
+// The hints required for this are only applied while the utility window is created;
+// afterwards, the previously set hints are restored.
+// As with any window, the caller must make the context current before issuing GL commands for it,
+// using MakeContextCurrent on the render thread that executes the loading work.
+func CreateUtilityWindow(share *Window) (*Window, error) {
+	if enqueue == nil {
+		return nil, fmt.Errorf("create utility window: %w", ErrNotInitialized)
+	}
 
-	return window, err
+	var s *glfw.Window
+	if share != nil {
+		s = share.Window
+	}
+
+	var err error
+	var window *Window
+	enqueue(true, func() {
+		setHint := func(target Hint, value int) {
+			windowHint(glfw.Hint(target), value)
+		}
+		window, err = createUtilityWindow(s, createWindow, setHint)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create utility window: %w", err)
+	}
+	return window, nil
+}
+
+// createUtilityWindow calls create for a 1x1 window sharing the context of share, with the utility window hints applied using setHint.
+// Afterwards, the hints set via WindowHint are restored. Must be called on the render thread.
+func createUtilityWindow(share *glfw.Window, create func(width, height int, title string, monitor *glfw.Monitor, share *glfw.Window) (*Window, error), setHint func(Hint, int)) (*Window, error) {
+	hints := []Hint{Visible, Decorated, Focused, FocusOnShow}
+	for _, hint := range hints {
+		setHint(hint, glfw.False)
+	}
+	w, err := create(1, 1, "", nil, share)
+	for _, hint := range hints {
+		setHint(hint, hintValue(hint, glfw.True))
+	}
+	return w, err
 }
 
+// CreateWindowAt is like CreateWindow, but places the window's content area at the given position, in screen coordinates.
+// The window is created in windowed mode.
+//
+// With glfw 3.4, the position is passed via the PositionX and PositionY hints, so the window appears at its final position.
+// With older glfw versions, the window is created hidden, moved and then shown if the Visible hint allows it,
+// which avoids the window briefly appearing at its default position.
+// In both cases, previously set hints are restored afterwards.
+func CreateWindowAt(x, y, width, height int, title string, share *Window) (*Window, error) {
+	var s *glfw.Window
+	if share != nil {
+		s = share.Window
+	}
+
+	if enqueue == nil {
+		return nil, fmt.Errorf("create window: %w", ErrNotInitialized)
+	}
+
+	var err error
+	var window *Window
+	enqueue(true, func() {
+		create := func() (*Window, error) {
+			return createWindow(width, height, title, nil, s)
+		}
+		setHint := func(target Hint, value int) {
+			windowHint(glfw.Hint(target), value)
+		}
+		native := func(w *Window) nativeWindow {
+			return w.Window
+		}
+		window, err = createWindowAt(x, y, create, setHint, native)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create window: %w", err)
+	}
+	return window, nil
+}
+
+// createWindowAt calls create with the PositionX and PositionY hints applied using setHint.
+// With older glfw versions, the window is created hidden instead, and moved and shown via its native window.
+// Afterwards, the hints set via WindowHint are restored. Must be called on the render thread.
+func createWindowAt(x, y int, create func() (*Window, error), setHint func(Hint, int), native func(*Window) nativeWindow) (*Window, error) {
+	if versionAtLeast(3, 4) {
+		setHint(PositionX, x)
+		setHint(PositionY, y)
+		w, err := create()
+		setHint(PositionX, hintValue(PositionX, AnyPosition))
+		setHint(PositionY, hintValue(PositionY, AnyPosition))
+		return w, err
+	}
+
+	visible := hintValue(Visible, glfw.True)
+	setHint(Visible, glfw.False)
+	w, err := create()
+	setHint(Visible, visible)
+	if err != nil {
+		return nil, err
+	}
+	win := native(w)
+	win.SetPos(x, y)
+	if visible == glfw.True {
+		win.Show()
+	}
+	return w, nil
+}
+
+// The context functions of glfw. They are replaceable to test context handling without a display.
+var (
+	getCurrentContext    = glfw.GetCurrentContext
+	makeContextCurrent   = (*glfw.Window).MakeContextCurrent
+	detachCurrentContext = glfw.DetachCurrentContext
+	swapInterval         = glfw.SwapInterval
+	extensionSupported   = glfw.ExtensionSupported
+)
+
 // SwapInterval sets the swap interval for the current context, i.e. the number
 // of screen updates to wait before swapping the buffers of a window and
 // returning from SwapBuffers. This is sometimes called
 // 'vertical synchronization', 'vertical retrace synchronization' or 'vsync'.
 func SwapInterval(interval int) {
 	enqueue(false, func() {
-		glfw.SwapInterval(interval)
+		swapInterval(interval)
+	})
+}
+
+// SwapInterval sets the swap interval for the window's context.
+// Unlike the global SwapInterval, the setting is remembered and reapplied
+// each time the window's context is made current using MakeContextCurrent.
+// If the context is not current, the interval is applied the next time it becomes current.
+func (w *Window) SwapInterval(interval int) {
+	enqueue(false, func() {
+		w.swapInterval, w.hasSwapInterval = interval, true
+		if getCurrentContext() == w.Window {
+			swapInterval(interval)
+		}
+	})
+}
+
+// ExtensionSupported reports whether the specified OpenGL or context creation
+// API extension is supported by the current context.
+//
+// A context must be current on the render thread.
+func ExtensionSupported(extension string) bool {
+	var supported bool
+	enqueue(true, func() {
+		supported = extensionSupported(extension)
+	})
+	return supported
+}
+
+// RequireExtensions checks whether all given extensions are supported by the current context.
+// The returned error lists every missing extension.
+//
+// A context must be current on the render thread.
+func RequireExtensions(exts ...string) error {
+	var missing []string
+	enqueue(true, func() {
+		for _, ext := range exts {
+			if !extensionSupported(ext) {
+				missing = append(missing, ext)
+			}
+		}
 	})
+	if len(missing) > 0 {
+		return fmt.Errorf("missing required extensions: %s", strings.Join(missing, ", "))
+	}
+	return nil
 }
 
 // MakeContextCurrent makes the context of the window current.
 func (w *Window) MakeContextCurrent() {
 	enqueue(false, func() {
-		w.Window.MakeContextCurrent()
+		makeContextCurrent(w.Window)
+		if w.hasSwapInterval {
+			swapInterval(w.swapInterval)
+		}
 		// In reality, context is available on each platform via GetGLXContext, GetWGLContext, GetNSGLContext, etc.
 		// Pretend it is not available and pass nil, since it's not actually needed at this time.
 		contextWatcher.OnMakeCurrent(nil)
 	})
 }
 
+// WithContext makes the context of the window current, calls fn and restores the previously current context,
+// all within a single command on the render thread.
+//
+// fn is called on the render thread and typically issues GL calls for the window.
+// The ContextWatcher is notified about each context change. If the window's context is already current, fn is simply called.
+// The swap interval set via Window.SwapInterval is not reapplied, as the context is only current temporarily.
+func (w *Window) WithContext(fn func()) {
+	enqueue(true, func() {
+		w.withCurrentContext(fn)
+	})
+}
+
+// IsContextCurrent reports whether the context of the window is current on the render thread.
+func (w *Window) IsContextCurrent() bool {
+	var current bool
+	enqueue(true, func() {
+		current = getCurrentContext() == w.Window
+	})
+	return current
+}
+
 func DetachCurrentContext() {
 	enqueue(false, func() {
-		glfw.DetachCurrentContext()
+		detachCurrentContext()
 		contextWatcher.OnDetach()
 	})
 }
@@ -101,28 +502,136 @@ func (w *Window) SwapBuffers() {
 	})
 }
 
+// Destroy destroys the window and its context.
+//
+// The window's reference to the cursor set via SetCursor is released, destroying the cursor
+// if nothing else references it. Icons set via SetIcon don't need cleanup, as glfw copies the images.
 func (w *Window) Destroy() {
-	enqueue(false, w.Window.Destroy)
+	w.stopAttention() // Before destroying, so that no further requests are enqueued.
+	enqueue(false, func() {
+		forgetLastFocused(w)
+		unregisterWindow(w)
+		w.Window.Destroy()
+	})
+	w.releaseCursor()
+	w.stopWakeup()
 }
 
 func (w *Window) SetTitle(title string) {
+	w.cancelThrottledTitle()
+	w.setTitle(title)
+}
+
+// setTitle applies the title, prefixed with the modified marker if set via SetModified.
+func (w *Window) setTitle(title string) {
+	w.mu.Lock()
+	w.baseTitle = title
+	if w.modified {
+		title = modifiedMarker + title
+	}
+	w.mu.Unlock()
+
 	enqueue(false, func() {
-		w.Window.SetTitle(title)
+		setWindowTitle(w.Window, title)
 	})
 }
 
+// setWindowTitle sets the title of a glfw window. Replaced by tests.
+var setWindowTitle = (*glfw.Window).SetTitle
+
 func (w *Window) SetPos(xpos, ypos int) {
 	enqueue(false, func() {
 		w.Window.SetPos(xpos, ypos)
 	})
 }
 
+// MoveBy moves the window by the given delta, in screen coordinates.
+// Reading the current position and applying the new one happens atomically on the render thread.
+func (w *Window) MoveBy(dx, dy int) {
+	enqueue(false, func() {
+		moveBy(w.Window, dx, dy)
+	})
+}
+
+// moveBy is like MoveBy. Must be called on the render thread.
+func moveBy(win nativeWindow, dx, dy int) {
+	x, y := win.GetPos()
+	win.SetPos(x+dx, y+dy)
+}
+
 func (w *Window) SetSize(width, height int) {
 	enqueue(false, func() {
 		w.Window.SetSize(width, height)
 	})
 }
 
+// GetGeometry returns the position and size of the window's content area, in screen coordinates.
+// Both are retrieved within a single round-trip to the render thread.
+func (w *Window) GetGeometry() (x, y, width, height int) {
+	enqueue(true, func() {
+		x, y, width, height = geometry(w.Window)
+	})
+	return x, y, width, height
+}
+
+// SetGeometry sets the position and size of the window's content area, in screen coordinates.
+func (w *Window) SetGeometry(x, y, width, height int) {
+	enqueue(false, func() {
+		setGeometry(w.Window, x, y, width, height)
+	})
+}
+
+// geometry returns the position and size of the native window. Must be called on the render thread.
+func geometry(win nativeWindow) (x, y, width, height int) {
+	x, y = win.GetPos()
+	width, height = win.GetSize()
+	return x, y, width, height
+}
+
+// setGeometry sets the position and size of the native window. Must be called on the render thread.
+func setGeometry(win nativeWindow, x, y, width, height int) {
+	win.SetPos(x, y)
+	win.SetSize(width, height)
+}
+
+// SetSizeLimits sets the size limits of the content area of the window, in screen coordinates.
+// To disable a limit, pass DontCare.
+func (w *Window) SetSizeLimits(minw, minh, maxw, maxh int) {
+	enqueue(false, func() {
+		w.Window.SetSizeLimits(minw, minh, maxw, maxh)
+	})
+}
+
+// SetSizeLimitsPixels sets the size limits of the content area of the window, in framebuffer pixels.
+// To disable a limit, pass DontCare.
+//
+// The limits are converted to screen coordinates using the current ratio between framebuffer size and window size.
+// This ratio is used instead of the content scale, since screen coordinates are equal to pixels on some platforms
+// (like Windows) regardless of the content scale.
+func (w *Window) SetSizeLimitsPixels(minw, minh, maxw, maxh int) {
+	enqueue(false, func() {
+		width, height := w.Window.GetSize()
+		fbWidth, fbHeight := w.Window.GetFramebufferSize()
+		scaleX, scaleY := 1.0, 1.0
+		if width > 0 && height > 0 && fbWidth > 0 && fbHeight > 0 {
+			scaleX = float64(fbWidth) / float64(width)
+			scaleY = float64(fbHeight) / float64(height)
+		}
+		w.Window.SetSizeLimits(
+			pixelsToScreen(minw, scaleX), pixelsToScreen(minh, scaleY),
+			pixelsToScreen(maxw, scaleX), pixelsToScreen(maxh, scaleY),
+		)
+	})
+}
+
+// pixelsToScreen converts a size in pixels to screen coordinates, rounding up. DontCare is preserved.
+func pixelsToScreen(size int, scale float64) int {
+	if size == DontCare {
+		return DontCare
+	}
+	return int(math.Ceil(float64(size) / scale))
+}
+
 func (w *Window) GetContentScale() (float32, float32) {
 	var x, y float32
 	enqueue(true, func() {
@@ -145,14 +654,69 @@ func (w *Window) SetOpacity(opacity float32) {
 	})
 }
 
+// OpacitySupported reports whether the window's opacity can be changed on this platform.
+// Where it's unsupported, glfw ignores SetOpacity and always reports an opacity of 1.
+//
+// Detection is heuristic: a different opacity is set and read back,
+// then the original opacity is restored within the same render thread command.
+func (w *Window) OpacitySupported() bool {
+	var supported bool
+	enqueue(true, func() {
+		supported = opacitySupported(w.Window)
+	})
+	return supported
+}
+
+// opacityWindow is the part of a glfw window controlling its opacity.
+type opacityWindow interface {
+	GetOpacity() float32
+	SetOpacity(opacity float32)
+}
+
+// opacitySupported probes whether the window's opacity can be changed. Must be called on the render thread.
+func opacitySupported(win opacityWindow) bool {
+	original := win.GetOpacity()
+	probe := float32(0.5)
+	if math.Abs(float64(original-probe)) < 0.1 {
+		probe = 0.25
+	}
+
+	win.SetOpacity(probe)
+	supported := math.Abs(float64(win.GetOpacity()-probe)) < 0.01
+	win.SetOpacity(original)
+	return supported
+}
+
+// Iconify iconifies (minimizes) the window.
+//
+// The call is ignored if the window is already iconified, according to its tracked state.
 func (w *Window) Iconify() {
+	if w.State() == WindowIconified {
+		return
+	}
 	enqueue(false, w.Window.Iconify)
 }
 
+// Restore restores the window if it was previously iconified (minimized) or maximized.
+//
+// The call is ignored if the window is already in its normal state, according to its tracked state.
 func (w *Window) Restore() {
+	if w.State() == WindowNormal {
+		return
+	}
 	enqueue(false, w.Window.Restore)
 }
 
+// Maximize maximizes the window.
+//
+// The call is ignored if the window is already maximized, according to its tracked state.
+func (w *Window) Maximize() {
+	if w.State() == WindowMaximized {
+		return
+	}
+	enqueue(false, w.Window.Maximize)
+}
+
 func (w *Window) Show() {
 	enqueue(false, w.Window.Show)
 }
@@ -161,9 +725,59 @@ func (w *Window) Hide() {
 	enqueue(false, w.Window.Hide)
 }
 
+// showTimeout is the maximum duration ShowAndWait waits for the window to be mapped.
+const showTimeout = 500 * time.Millisecond
+
+// ShowAndWait makes the window visible and waits until it has been mapped by the window system,
+// signaled by the first refresh event.
+//
+// On some platforms, rendering to a window before it is mapped has no effect, losing the first frame.
+// Events are processed while waiting. If no refresh event arrives within a short timeout,
+// ShowAndWait returns anyway, since some window systems don't send one.
+func (w *Window) ShowAndWait() {
+	mapped := make(chan struct{})
+	w.mu.Lock()
+	w.refreshWaiters = append(w.refreshWaiters, mapped)
+	w.mu.Unlock()
+
+	enqueue(false, w.Window.Show)
+
+	deadline := time.Now().Add(showTimeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-mapped:
+			return
+		default:
+		}
+		enqueueEvents(true, func() {
+			glfw.WaitEventsTimeout(0.01)
+			flushCoalescedEvents()
+		})
+	}
+}
+
+// ShowWithoutFocus makes the window visible without giving it input focus.
+// The FocusOnShow attribute is temporarily disabled and restored afterwards.
+//
+// This is a request to the window manager; some window managers ignore it and focus the window regardless.
+func (w *Window) ShowWithoutFocus() {
+	enqueue(false, func() {
+		showWithoutFocus(w.Window)
+	})
+}
+
+// showWithoutFocus implements ShowWithoutFocus for the native window. Must be called on the render thread.
+func showWithoutFocus(win nativeWindow) {
+	focusOnShow := win.GetAttrib(glfw.FocusOnShow)
+	win.SetAttrib(glfw.FocusOnShow, glfw.False)
+	win.Show()
+	win.SetAttrib(glfw.FocusOnShow, focusOnShow)
+}
+
 // SetAttrib function sets the value of an attribute of the specified window.
 //
-// The supported attributes are Decorated, Resizeable, Floating and AutoIconify.
+// The supported attributes are Decorated, Resizeable, Floating, AutoIconify and FocusOnShow.
+// MousePassthrough requires glfw 3.4, use SetMousePassthrough to set it safely.
 //
 // Some of these attributes are ignored for full screen windows. The new value
 // will take effect if the window is later made windowed.
@@ -180,6 +794,9 @@ func (w *Window) SetAttrib(attrib Hint, value int) {
 
 // GetAttrib returns an attribute of the window. There are many attributes,
 // some related to the window and others to its context.
+//
+// The value is queried live from glfw. For Iconified, Maximized and Focused,
+// prefer the methods of the same name, which report the state tracked by callbacks.
 func (w *Window) GetAttrib(attrib Hint) int {
 	var val int
 	enqueue(true, func() {
@@ -188,51 +805,234 @@ func (w *Window) GetAttrib(attrib Hint) int {
 	return val
 }
 
+// ContextNoError reports whether the window's context was created with the ContextNoError hint.
+//
+// Such a context doesn't report GL errors. Any situation that would have generated an error causes undefined behavior instead.
+func (w *Window) ContextNoError() bool {
+	return w.boolAttrib(ContextNoError)
+}
+
+// IsForwardCompatible reports whether the window's context is an OpenGL forward-compatible one.
+func (w *Window) IsForwardCompatible() bool {
+	return w.boolAttrib(OpenGLForwardCompatible)
+}
+
+// IsDebugContext reports whether the window's context is an OpenGL debug context.
+func (w *Window) IsDebugContext() bool {
+	return w.boolAttrib(OpenGLDebugContext)
+}
+
+// boolAttrib reads a boolean window attribute.
+func (w *Window) boolAttrib(attrib Hint) bool {
+	var enabled bool
+	enqueue(true, func() {
+		enabled = attribEnabled(w.Window, attrib)
+	})
+	return enabled
+}
+
+// attribEnabled reports whether the boolean attribute is set on the native window. Must be called on the render thread.
+func attribEnabled(win nativeWindow, attrib Hint) bool {
+	return win.GetAttrib(glfw.Hint(attrib)) == glfw.True
+}
+
+// SetMousePassthrough defines whether the window is transparent to mouse input,
+// letting mouse events pass through to whatever window is behind it.
+//
+// Mouse passthrough requires glfw 3.4 and is supported on Windows, macOS and X11 (with the XShape extension).
+// With older glfw versions, like the one bundled with go-gl/glfw v3.3, the call is ignored.
+func (w *Window) SetMousePassthrough(enabled bool) {
+	enqueue(false, func() {
+		setMousePassthrough(w.Window, enabled)
+	})
+}
+
+// MousePassthrough reports whether the window is transparent to mouse input.
+// Always false if glfw 3.4 is not available.
+func (w *Window) MousePassthrough() bool {
+	var enabled bool
+	enqueue(true, func() {
+		enabled = mousePassthrough(w.Window)
+	})
+	return enabled
+}
+
+// setMousePassthrough sets the MousePassthrough attribute if glfw supports it. Must be called on the render thread.
+func setMousePassthrough(win nativeWindow, enabled bool) {
+	if !versionAtLeast(3, 4) {
+		return
+	}
+	win.SetAttrib(glfw.Hint(MousePassthrough), boolHint(enabled))
+}
+
+// mousePassthrough reads the MousePassthrough attribute if glfw supports it. Must be called on the render thread.
+func mousePassthrough(win nativeWindow) bool {
+	return versionAtLeast(3, 4) && attribEnabled(win, MousePassthrough)
+}
+
+// RobustnessStrategy returns the robustness strategy used by the window's context,
+// which is NoRobustness, NoResetNotification or LoseContextOnReset.
+//
+// The ContextRobustness hint is only a request; this allows to verify which strategy the driver provided.
+func (w *Window) RobustnessStrategy() int {
+	var strategy int
+	enqueue(true, func() {
+		strategy = robustnessStrategy(w.Window)
+	})
+	return strategy
+}
+
+// robustnessStrategy reads the ContextRobustness attribute. Must be called on the render thread.
+func robustnessStrategy(win nativeWindow) int {
+	return win.GetAttrib(glfw.Hint(ContextRobustness))
+}
+
 func (w *Window) SetClipboardString(str string) {
 	enqueue(false, func() {
 		w.Window.SetClipboardString(str)
 	})
 }
 
+// GetClipboardString returns the contents of the clipboard as string.
+// An empty string is returned if the clipboard is empty or its contents can't be converted to a string;
+// use GetClipboardStringErr to tell these cases apart.
 func (w *Window) GetClipboardString() string {
-	var s string
-	enqueue(false, func() {
-		s = w.Window.GetClipboardString()
-	})
+	s, _ := w.GetClipboardStringErr()
 	return s
 }
 
 type Window struct {
 	*glfw.Window
+
+	input        inputState
+	focused      int32 // Accessed atomically; 1 if the window has input focus.
+	hintFallback int   // Index of the fallback used by CreateWindowWithFallback. Not modified after creation.
+
+	closed     chan struct{} // Closed once the window is flagged for closing.
+	closedOnce sync.Once
+
+	// Swap interval set via Window.SwapInterval. Only accessed on the render thread.
+	swapInterval    int
+	hasSwapInterval bool
+
+	windowedGeometry *[4]int          // Position and size before becoming full screen; x, y, width, height. Only accessed on the render thread.
+	iconifiedByAll   bool             // Whether IconifyAll iconified the window. Only accessed on the render thread.
+	borderless       *borderlessState // Saved by SetBorderlessFullscreen; nil if not borderless full screen. Only accessed on the render thread.
+
+	mu sync.Mutex // Guards all fields below.
+
+	// Callbacks registered by the user.
+	cursorPosCallback       CursorPosCallback
+	keyCallback             KeyCallback
+	charCallback            CharCallback
+	scrollCallback          ScrollCallback
+	mouseButtonCallback     MouseButtonCallback
+	maximizeCallback        MaximizeCallback
+	maximizeChangeCallback  func(w *Window, maximized bool)
+	framebufferSizeCallback FramebufferSizeCallback
+	iconifyCallback         IconifyCallback
+	focusCallback           FocusCallback
+	cursorEnterCallback     CursorEnterCallback
+	scancodeCallback        ScancodeCallback
+	sizeCallback            SizeCallback
+	closeCallback           CloseCallback
+	contentScaleCallback    ContentScaleCallback
+	scaleChangeCallback     ScaleChangeCallback
+	observers               []*observer // Registered via Observe and OnResize.
+	refreshCallback         RefreshCallback
+	continuousRefresh       RefreshCallback
+	resizeRender            func(w *Window)
+	refreshWaiters          []chan struct{} // Closed on the next refresh event.
+
+	// State tracked by callbacks.
+	state            WindowState
+	restoreMaximized bool    // Whether the window returns to the maximized state once it is no longer iconified.
+	maximized        bool    // Last value reported to the maximize change callback.
+	contentScale     float32 // Horizontal content scale.
+	fbRatio          framebufferRatio
+
+	pauseUnfocused     bool        // Whether rendering should pause while the window is unfocused or iconified.
+	attentionTimer     *time.Timer // Repeats attention requests until focused; nil if not requested.
+	rawFramebufferSize bool        // Whether framebuffer size events with a zero dimension are delivered.
+
+	cursor       *Cursor // Set via SetCursor; the window holds a reference to it.
+	titleBarDrag titleBarDrag
+	hover        hoverIntent
+
+	interactiveRegions []image.Rectangle // Nil if passthrough is not managed by SetInteractiveRegions.
+	passthrough        bool              // Whether passthrough was enabled for interactiveRegions.
+
+	// Redrawing in RunEventLoop.
+	redrawPolicy    RedrawPolicy
+	redrawOnEvent   bool // Set by input, resize and refresh events.
+	redrawRequested bool // Set by RequestRedraw.
+
+	// Frame limiting, in seconds of glfw time.
+	frameInterval float64 // Zero if unlimited.
+	nextFrame     float64
+
+	// Throttled title updates.
+	baseTitle    string // Last title set, without modified marker.
+	modified     bool   // Whether the title is prefixed with modifiedMarker.
+	pendingTitle string
+	titleTimer   throttleTimer // Applies pendingTitle; nil if no throttled update is pending.
+	titleSetAt   time.Time
+
+	// Input processing.
+	recorder        *inputRecorder // Nil if not recording.
+	keyRemap        map[Key]Key
+	keyDebounce     keyDebounce
+	eventFilter     EventFilter
+	mouseDelta      mouseDelta
+	smoothScroll    *smoothScroll
+	naturalScroll   bool
+	clampCursor     bool
+	cursorDisabled  bool // Whether the cursor mode is CursorDisabled.
+	scrollScaleDPI  bool
+	inputChannels   []*inputChannel
+	overflowPolicy  OverflowPolicy
+	coalescedCursor coalescedCursorPos
+
+	// Served by a goroutine started by WakeupChannel.
+	wakeup     chan struct{}
+	wakeupDone chan struct{} // Closed when the window is destroyed.
 }
 
 type Monitor struct {
 	*glfw.Monitor
 }
 
+// getPrimaryMonitor returns the primary glfw monitor, or nil if no monitor is connected. Replaced by tests.
+var getPrimaryMonitor = glfw.GetPrimaryMonitor
+
+// GetPrimaryMonitor returns the primary monitor, or nil if no monitor is connected,
+// for example on headless systems.
 func GetPrimaryMonitor() *Monitor {
 	var m *glfw.Monitor
 	enqueue(true, func() {
-		m = glfw.GetPrimaryMonitor()
+		m = getPrimaryMonitor()
 	})
+	if m == nil {
+		return nil
+	}
 	return &Monitor{Monitor: m}
 }
 
+// PollEvents processes all pending events.
+// Event processing is scheduled with priority if the render thread implements PriorityRenderThread.
 func PollEvents() {
-	enqueue(true, func() {
+	enqueueEvents(true, func() {
 		glfw.PollEvents()
+		flushCoalescedEvents()
 	})
 }
 
 type CursorPosCallback func(w *Window, xpos float64, ypos float64)
 
 func (w *Window) SetCursorPosCallback(cbfun CursorPosCallback) (previous CursorPosCallback) {
-	wrappedCbfun := func(_ *glfw.Window, xpos float64, ypos float64) {
-		cbfun(w, xpos, ypos)
-	}
-
-	p := w.Window.SetCursorPosCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.cursorPosCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -240,27 +1040,23 @@ func (w *Window) SetCursorPosCallback(cbfun CursorPosCallback) (previous CursorP
 
 type KeyCallback func(w *Window, key Key, scancode int, action Action, mods ModifierKey)
 
+// SetKeyCallback sets the key callback, which is called when a key is pressed, repeated or released.
+// It returns the previously set callback, or nil if there was none, so that it can be restored or chained.
+// Passing nil removes the callback.
 func (w *Window) SetKeyCallback(cbfun KeyCallback) (previous KeyCallback) {
-	wrappedCbfun := func(_ *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
-		cbfun(w, Key(key), scancode, Action(action), ModifierKey(mods))
-	}
-
-	p := w.Window.SetKeyCallback(wrappedCbfun)
-	_ = p
-
-	// TODO: Handle previous.
-	return nil
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	previous = w.keyCallback
+	w.keyCallback = cbfun
+	return previous
 }
 
 type CharCallback func(w *Window, char rune)
 
 func (w *Window) SetCharCallback(cbfun CharCallback) (previous CharCallback) {
-	wrappedCbfun := func(_ *glfw.Window, char rune) {
-		cbfun(w, char)
-	}
-
-	p := w.Window.SetCharCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.charCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -269,12 +1065,9 @@ func (w *Window) SetCharCallback(cbfun CharCallback) (previous CharCallback) {
 type ScrollCallback func(w *Window, xoff float64, yoff float64)
 
 func (w *Window) SetScrollCallback(cbfun ScrollCallback) (previous ScrollCallback) {
-	wrappedCbfun := func(_ *glfw.Window, xoff float64, yoff float64) {
-		cbfun(w, xoff, yoff)
-	}
-
-	p := w.Window.SetScrollCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.scrollCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -283,12 +1076,9 @@ func (w *Window) SetScrollCallback(cbfun ScrollCallback) (previous ScrollCallbac
 type MouseButtonCallback func(w *Window, button MouseButton, action Action, mods ModifierKey)
 
 func (w *Window) SetMouseButtonCallback(cbfun MouseButtonCallback) (previous MouseButtonCallback) {
-	wrappedCbfun := func(_ *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
-		cbfun(w, MouseButton(button), Action(action), ModifierKey(mods))
-	}
-
-	p := w.Window.SetMouseButtonCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.mouseButtonCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -297,12 +1087,9 @@ func (w *Window) SetMouseButtonCallback(cbfun MouseButtonCallback) (previous Mou
 type FramebufferSizeCallback func(w *Window, width int, height int)
 
 func (w *Window) SetFramebufferSizeCallback(cbfun FramebufferSizeCallback) (previous FramebufferSizeCallback) {
-	wrappedCbfun := func(_ *glfw.Window, width int, height int) {
-		cbfun(w, width, height)
-	}
-
-	p := w.Window.SetFramebufferSizeCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.framebufferSizeCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -323,12 +1110,19 @@ func (w *Window) GetInputMode(mode InputMode) int {
 }
 
 func (w *Window) SetInputMode(mode InputMode, value int) {
+	if mode == CursorMode {
+		w.resetMouseDelta()
+	}
 	w.Window.SetInputMode(glfw.InputMode(mode), value)
+	if mode == CursorMode {
+		w.trackCursorMode(value)
+	}
 }
 
 type Key glfw.Key
 
 const (
+	KeyUnknown      = Key(glfw.KeyUnknown)
 	KeySpace        = Key(glfw.KeySpace)
 	KeyApostrophe   = Key(glfw.KeyApostrophe)
 	KeyComma        = Key(glfw.KeyComma)
@@ -636,6 +1430,8 @@ const (
 	CursorMode             = InputMode(glfw.CursorMode)
 	StickyKeysMode         = InputMode(glfw.StickyKeysMode)
 	StickyMouseButtonsMode = InputMode(glfw.StickyMouseButtonsMode)
+	LockKeyMods            = InputMode(glfw.LockKeyMods)
+	RawMouseMotion         = InputMode(glfw.RawMouseMotion)
 )
 
 const (
@@ -675,57 +1471,98 @@ func (m ModifierKey) String() string {
 
 // Open opens a named asset. It's the caller's responsibility to close it when done.
 //
-// For now, assets are read directly from the current working directory.
+// Relative names are resolved against the asset base directory, see SetAssetBaseDir.
+// Absolute names are opened unchanged.
 func Open(name string) (io.ReadCloser, error) {
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(assetDir(), name)
+	}
 	return os.Open(name)
 }
 
+var (
+	assetBaseDirMu sync.Mutex
+	assetBaseDir   string
+)
+
+// SetAssetBaseDir sets the directory relative asset names are resolved against by Open.
+//
+// By default, assets are resolved relative to the directory containing the executable,
+// so they are found regardless of the working directory the application was launched from.
+// Note that binaries built by "go run" are placed in a temporary directory.
+// Passing an empty string restores the default.
+func SetAssetBaseDir(dir string) {
+	assetBaseDirMu.Lock()
+	assetBaseDir = dir
+	assetBaseDirMu.Unlock()
+}
+
+// assetDir returns the directory relative asset names are resolved against.
+// Falls back to the working directory if the executable's location is unknown.
+func assetDir() string {
+	assetBaseDirMu.Lock()
+	dir := assetBaseDir
+	assetBaseDirMu.Unlock()
+	if dir != "" {
+		return dir
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		return ""
+	}
+	return filepath.Dir(exe)
+}
+
 // ---
 
 func WaitEvents() {
-	enqueue(true, func() {
+	enqueueEvents(true, func() {
 		glfw.WaitEvents()
+		flushCoalescedEvents()
 	})
 }
 
 func PostEmptyEvent() {
-	glfw.PostEmptyEvent()
+	postEmptyEvent()
+}
+
+// postEmptyEvent wakes up event processing. Replaced by tests.
+var postEmptyEvent = glfw.PostEmptyEvent
+
+// GetTime returns the value of the glfw timer, in seconds since glfw was initialized.
+// Unlike most functions, it can be called from any goroutine.
+func GetTime() float64 {
+	return glfw.GetTime()
 }
 
 func DefaultWindowHints() {
-	enqueue(false, func() {
-		glfw.DefaultWindowHints()
-	})
+	forgetHintValues()
+	enqueue(false, restoreHints)
 }
 
 type CloseCallback func(w *Window)
 
 func (w *Window) SetCloseCallback(cbfun CloseCallback) (previous CloseCallback) {
-	wrappedCbfun := func(_ *glfw.Window) {
-		cbfun(w)
-	}
-
-	p := w.Window.SetCloseCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.closeCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
 }
 
 // MaximizeCallback is the function signature for window maximize callback functions.
-type MaximizeCallback func(w *Window, iconified bool)
+type MaximizeCallback func(w *Window, maximized bool)
 
 // SetMaximizeCallback sets the maximization callback of the specified window,
 // which is called when the window is maximized or restored.
 //
 // This function must only be called from the main thread.
 func (w *Window) SetMaximizeCallback(cbfun MaximizeCallback) MaximizeCallback {
-	wrappedCbfun := func(_ *glfw.Window, iconified bool) {
-		cbfun(w, iconified)
-	}
-
-	p := w.Window.SetMaximizeCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.maximizeCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -741,12 +1578,9 @@ type ContentScaleCallback func(w *Window, x, y float32)
 //
 // This function must only be called from the main thread.
 func (w *Window) SetContentScaleCallback(cbfun ContentScaleCallback) ContentScaleCallback {
-	wrappedCbfun := func(_ *glfw.Window, x, y float32) {
-		cbfun(w, x, y)
-	}
-
-	p := w.Window.SetContentScaleCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.contentScaleCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -755,12 +1589,9 @@ func (w *Window) SetContentScaleCallback(cbfun ContentScaleCallback) ContentScal
 type RefreshCallback func(w *Window)
 
 func (w *Window) SetRefreshCallback(cbfun RefreshCallback) (previous RefreshCallback) {
-	wrappedCbfun := func(_ *glfw.Window) {
-		cbfun(w)
-	}
-
-	p := w.Window.SetRefreshCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.refreshCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -769,12 +1600,9 @@ func (w *Window) SetRefreshCallback(cbfun RefreshCallback) (previous RefreshCall
 type SizeCallback func(w *Window, width int, height int)
 
 func (w *Window) SetSizeCallback(cbfun SizeCallback) (previous SizeCallback) {
-	wrappedCbfun := func(_ *glfw.Window, width int, height int) {
-		cbfun(w, width, height)
-	}
-
-	p := w.Window.SetSizeCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.sizeCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -783,12 +1611,9 @@ func (w *Window) SetSizeCallback(cbfun SizeCallback) (previous SizeCallback) {
 type CursorEnterCallback func(w *Window, entered bool)
 
 func (w *Window) SetCursorEnterCallback(cbfun CursorEnterCallback) (previous CursorEnterCallback) {
-	wrappedCbfun := func(_ *glfw.Window, entered bool) {
-		cbfun(w, entered)
-	}
-
-	p := w.Window.SetCursorEnterCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.cursorEnterCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -811,12 +1636,9 @@ func (w *Window) SetPosCallback(cbfun PosCallback) (previous PosCallback) {
 type FocusCallback func(w *Window, focused bool)
 
 func (w *Window) SetFocusCallback(cbfun FocusCallback) (previous FocusCallback) {
-	wrappedCbfun := func(_ *glfw.Window, focused bool) {
-		cbfun(w, focused)
-	}
-
-	p := w.Window.SetFocusCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.focusCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
@@ -825,12 +1647,9 @@ func (w *Window) SetFocusCallback(cbfun FocusCallback) (previous FocusCallback)
 type IconifyCallback func(w *Window, iconified bool)
 
 func (w *Window) SetIconifyCallback(cbfun IconifyCallback) (previous IconifyCallback) {
-	wrappedCbfun := func(_ *glfw.Window, iconified bool) {
-		cbfun(w, iconified)
-	}
-
-	p := w.Window.SetIconifyCallback(wrappedCbfun)
-	_ = p
+	w.mu.Lock()
+	w.iconifyCallback = cbfun
+	w.mu.Unlock()
 
 	// TODO: Handle previous.
 	return nil
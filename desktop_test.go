@@ -0,0 +1,665 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// stubEnqueue executes commands synchronously on the calling goroutine, instead of on a render thread.
+// It is intended for testing code paths that don't call into glfw. Call the returned function to undo it.
+func stubEnqueue() (restore func()) {
+	previous, previousPriority := enqueue, enqueuePriority
+	enqueue = func(_ bool, fn func()) { fn() }
+	enqueuePriority = nil
+	return func() {
+		enqueue, enqueuePriority = previous, previousPriority
+	}
+}
+
+func TestPixelsToScreen(t *testing.T) {
+	tests := []struct {
+		name  string
+		size  int
+		scale float64
+		want  int
+	}{
+		{"unscaled", 640, 1, 640},
+		{"retina", 640, 2, 320},
+		{"rounded up", 641, 2, 321},
+		{"fractional scale", 100, 1.5, 67},
+		{"don't care", DontCare, 2, DontCare},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pixelsToScreen(tt.size, tt.scale); got != tt.want {
+				t.Errorf("got %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestShowWithoutFocus(t *testing.T) {
+	for _, focusOnShow := range []int{glfw.True, glfw.False} {
+		win := &fakeNativeWindow{attribs: map[glfw.Hint]int{glfw.FocusOnShow: focusOnShow}}
+		showWithoutFocus(win)
+		if len(win.shown) != 1 || win.shown[0][glfw.FocusOnShow] != glfw.False {
+			t.Errorf("FocusOnShow %d: shown with attributes %v, want FocusOnShow disabled", focusOnShow, win.shown)
+		}
+		if got := win.attribs[glfw.FocusOnShow]; got != focusOnShow {
+			t.Errorf("FocusOnShow %d: not restored, got %d", focusOnShow, got)
+		}
+	}
+}
+
+func TestMousePassthrough(t *testing.T) {
+	previous := linkedVersion
+	defer func() { linkedVersion = previous }()
+
+	linkedVersion = func() (int, int, int) { return 3, 3, 8 }
+	win := &fakeNativeWindow{attribs: map[glfw.Hint]int{}}
+	setMousePassthrough(win, true)
+	if len(win.attribs) != 0 {
+		t.Errorf("glfw 3.3: attribute set to %v", win.attribs)
+	}
+	win.attribs[glfw.Hint(MousePassthrough)] = glfw.True
+	if mousePassthrough(win) {
+		t.Error("glfw 3.3: passthrough reported as enabled")
+	}
+
+	linkedVersion = func() (int, int, int) { return 3, 4, 0 }
+	for _, enabled := range []bool{true, false} {
+		setMousePassthrough(win, enabled)
+		if got := mousePassthrough(win); got != enabled {
+			t.Errorf("glfw 3.4: set %v, got %v", enabled, got)
+		}
+	}
+}
+
+func TestRenderThreadAlive(t *testing.T) {
+	defer stubEnqueue()()
+	if !RenderThreadAlive(time.Second) {
+		t.Error("responsive render thread reported as stalled")
+	}
+
+	var stalled []func()
+	enqueue = func(blocking bool, fn func()) {
+		if blocking {
+			t.Error("probe enqueued blocking")
+		}
+		stalled = append(stalled, fn)
+	}
+	if RenderThreadAlive(time.Millisecond) {
+		t.Error("stalled render thread reported as alive")
+	}
+	for _, fn := range stalled {
+		fn() // The render thread recovers and executes the late probe.
+	}
+}
+
+func TestFlush(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+
+	queue := make(chan func(), 8)
+	defer close(queue)
+	go func() {
+		for fn := range queue {
+			fn()
+		}
+	}()
+	enqueue = func(blocking bool, fn func()) {
+		if !blocking {
+			queue <- fn
+			return
+		}
+		done := make(chan struct{})
+		queue <- func() {
+			fn()
+			close(done)
+		}
+		<-done
+	}
+
+	win := &fakeNativeWindow{attribs: map[glfw.Hint]int{}}
+	enqueue(false, func() {
+		time.Sleep(10 * time.Millisecond) // A slow setter, still running when Flush is called.
+		win.SetAttrib(glfw.Floating, glfw.True)
+	})
+	Flush()
+	if win.attribs[glfw.Floating] != glfw.True {
+		t.Error("Flush returned before the pending setter was executed")
+	}
+}
+
+func TestEnqueueEvents(t *testing.T) {
+	previous, previousPriority := enqueue, enqueuePriority
+	defer func() { enqueue, enqueuePriority = previous, previousPriority }()
+
+	// A busy render thread with pending regular commands, which executes priority commands first once it is free.
+	var mu sync.Mutex
+	var regular, priority []func()
+	var log []string
+	enqueue = func(_ bool, fn func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		regular = append(regular, fn)
+	}
+	queued := make(chan struct{})
+	enqueuePriority = func(fn func()) {
+		mu.Lock()
+		defer mu.Unlock()
+		priority = append(priority, fn)
+		close(queued)
+	}
+	next := func() func() {
+		mu.Lock()
+		defer mu.Unlock()
+		queue := &regular
+		if len(priority) > 0 {
+			queue = &priority
+		}
+		fn := (*queue)[0]
+		*queue = (*queue)[1:]
+		return fn
+	}
+
+	enqueue(false, func() { log = append(log, "render 1") })
+	enqueue(false, func() { log = append(log, "render 2") })
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		enqueueEvents(true, func() { log = append(log, "events") })
+	}()
+	<-queued
+	for i := 0; i < 3; i++ {
+		next()()
+	}
+	<-done
+	if want := []string{"events", "render 1", "render 2"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("got order %v, want %v", log, want)
+	}
+
+	log = nil
+	enqueuePriority = nil
+	enqueueEvents(false, func() { log = append(log, "events") })
+	next()()
+	if want := []string{"events"}; !reflect.DeepEqual(log, want) {
+		t.Errorf("without priority support: got %v, want events enqueued regularly", log)
+	}
+}
+
+func TestRequireExtensions(t *testing.T) {
+	defer stubEnqueue()()
+	previous := extensionSupported
+	defer func() { extensionSupported = previous }()
+
+	var queried []string
+	extensionSupported = func(ext string) bool {
+		queried = append(queried, ext)
+		return ext == "GL_ARB_debug_output"
+	}
+
+	if err := RequireExtensions("GL_ARB_debug_output"); err != nil {
+		t.Errorf("supported extension: got error %q", err)
+	}
+	err := RequireExtensions("GL_ARB_bindless_texture", "GL_ARB_debug_output", "GL_ARB_sparse_texture")
+	if want := "missing required extensions: GL_ARB_bindless_texture, GL_ARB_sparse_texture"; err == nil || err.Error() != want {
+		t.Errorf("got error %v, want %q", err, want)
+	}
+	if len(queried) != 4 {
+		t.Errorf("queried %v, want every extension queried once", queried)
+	}
+}
+
+func TestShowAndWait(t *testing.T) {
+	previous, previousPriority := enqueue, enqueuePriority
+	defer func() { enqueue, enqueuePriority = previous, previousPriority }()
+
+	w := new(Window)
+	var refreshed int
+	w.SetRefreshCallback(func(*Window) { refreshed++ })
+
+	// The window is mapped while processing events for the second time.
+	var shown, rounds int
+	enqueuePriority = nil
+	enqueue = func(blocking bool, _ func()) {
+		if !blocking {
+			shown++
+			return
+		}
+		rounds++
+		if rounds == 2 {
+			w.onRefresh()
+		}
+	}
+
+	start := time.Now()
+	w.ShowAndWait()
+	if elapsed := time.Since(start); elapsed >= showTimeout {
+		t.Errorf("returned after %v, the refresh event was missed", elapsed)
+	}
+	if shown != 1 || rounds != 2 {
+		t.Errorf("shown %d times and processed events %d times, want 1 and 2", shown, rounds)
+	}
+	if refreshed != 1 {
+		t.Errorf("refresh callback called %d times, want 1", refreshed)
+	}
+}
+
+func TestOpen(t *testing.T) {
+	dir, err := ioutil.TempDir("", "assets")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "shader.glsl"), []byte("void main() {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	defer SetAssetBaseDir("")
+
+	read := func(name string) (string, error) {
+		f, err := Open(name)
+		if err != nil {
+			return "", err
+		}
+		defer f.Close()
+		content, err := ioutil.ReadAll(f)
+		return string(content), err
+	}
+
+	exe, err := os.Executable()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := assetDir(); got != filepath.Dir(exe) {
+		t.Errorf("default asset dir: got %q, want the executable's directory %q", got, filepath.Dir(exe))
+	}
+
+	SetAssetBaseDir(dir)
+	if content, err := read("shader.glsl"); err != nil || content != "void main() {}" {
+		t.Errorf("relative to the base dir: got %q, %v", content, err)
+	}
+
+	SetAssetBaseDir(os.TempDir())
+	if content, err := read(filepath.Join(dir, "shader.glsl")); err != nil || content != "void main() {}" {
+		t.Errorf("absolute name: got %q, %v", content, err)
+	}
+}
+
+func TestMoveBy(t *testing.T) {
+	win := &fakeNativeWindow{x: 100, y: 50}
+	moveBy(win, 15, -20)
+	if x, y := win.GetPos(); x != 115 || y != 30 {
+		t.Errorf("got position (%d, %d), want (115, 30)", x, y)
+	}
+	moveBy(win, -115, 0)
+	if x, y := win.GetPos(); x != 0 || y != 30 {
+		t.Errorf("got position (%d, %d), want (0, 30)", x, y)
+	}
+}
+
+func TestGeometry(t *testing.T) {
+	win := &fakeNativeWindow{x: 1, y: 2, width: 3, height: 4}
+	setGeometry(win, -100, 50, 800, 600)
+	x, y, width, height := geometry(win)
+	if got, want := [4]int{x, y, width, height}, [4]int{-100, 50, 800, 600}; got != want {
+		t.Errorf("got geometry %v, want %v", got, want)
+	}
+
+	// Each call is a single command.
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	var commands []bool // Whether each command was blocking.
+	enqueue = func(blocking bool, fn func()) { commands = append(commands, blocking) } // Never call into glfw.
+	w := new(Window)
+	w.SetGeometry(0, 0, 640, 480)
+	w.GetGeometry()
+	if want := []bool{false, true}; !reflect.DeepEqual(commands, want) {
+		t.Errorf("got commands %v (blocking), want %v", commands, want)
+	}
+}
+
+// fakeContexts emulates glfw's current context, and records context changes and swap intervals.
+type fakeContexts struct {
+	current *glfw.Window
+	log     []string
+}
+
+func (f *fakeContexts) OnMakeCurrent(context interface{}) { f.log = append(f.log, "watcher current") }
+func (f *fakeContexts) OnDetach()                         { f.log = append(f.log, "watcher detach") }
+
+// stubContexts replaces the glfw context functions and the ContextWatcher by a fakeContexts.
+// Call the returned function to undo it.
+func stubContexts(names map[*glfw.Window]string) (*fakeContexts, func()) {
+	f := new(fakeContexts)
+	previousGet, previousMake, previousDetach, previousSwap := getCurrentContext, makeContextCurrent, detachCurrentContext, swapInterval
+	previousWatcher := contextWatcher
+	getCurrentContext = func() *glfw.Window { return f.current }
+	makeContextCurrent = func(w *glfw.Window) {
+		f.current = w
+		f.log = append(f.log, "current "+names[w])
+	}
+	detachCurrentContext = func() {
+		f.current = nil
+		f.log = append(f.log, "detach")
+	}
+	swapInterval = func(interval int) { f.log = append(f.log, fmt.Sprintf("interval %d", interval)) }
+	contextWatcher = f
+	return f, func() {
+		getCurrentContext, makeContextCurrent, detachCurrentContext, swapInterval = previousGet, previousMake, previousDetach, previousSwap
+		contextWatcher = previousWatcher
+	}
+}
+
+func TestIsContextCurrent(t *testing.T) {
+	defer stubEnqueue()()
+	a, b := &Window{Window: new(glfw.Window)}, &Window{Window: new(glfw.Window)}
+	_, restore := stubContexts(map[*glfw.Window]string{a.Window: "a", b.Window: "b"})
+	defer restore()
+
+	if a.IsContextCurrent() || b.IsContextCurrent() {
+		t.Error("context reported current before any was made current")
+	}
+	a.MakeContextCurrent()
+	if !a.IsContextCurrent() || b.IsContextCurrent() {
+		t.Error("only the context of a should be current")
+	}
+	DetachCurrentContext()
+	if a.IsContextCurrent() {
+		t.Error("detached context reported current")
+	}
+}
+
+func TestWindowSwapInterval(t *testing.T) {
+	defer stubEnqueue()()
+	a, b := &Window{Window: new(glfw.Window)}, &Window{Window: new(glfw.Window)}
+	contexts, restore := stubContexts(map[*glfw.Window]string{a.Window: "a", b.Window: "b"})
+	defer restore()
+
+	a.SwapInterval(1) // Not current, applied once made current.
+	a.MakeContextCurrent()
+	b.MakeContextCurrent()
+	b.SwapInterval(0)
+	a.MakeContextCurrent()
+	b.MakeContextCurrent()
+
+	want := []string{
+		"current a", "interval 1", "watcher current",
+		"current b", "watcher current",
+		"interval 0",
+		"current a", "interval 1", "watcher current",
+		"current b", "interval 0", "watcher current",
+	}
+	if !reflect.DeepEqual(contexts.log, want) {
+		t.Errorf("got %v, want %v", contexts.log, want)
+	}
+}
+
+func TestTerminateAndWait(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+
+	// A render thread that only gets to execute commands when a blocking command is enqueued.
+	var pending []func()
+	enqueue = func(blocking bool, fn func()) {
+		pending = append(pending, fn)
+		for blocking && len(pending) > 0 {
+			next := pending[0]
+			pending = pending[1:]
+			next()
+		}
+	}
+
+	initialized = true
+	var log []string
+	enqueue(false, func() {
+		if !initialized {
+			t.Error("pending command executed after terminating")
+		}
+		log = append(log, "destroy")
+	})
+	TerminateAndWait()
+	if initialized || len(pending) != 0 || !reflect.DeepEqual(log, []string{"destroy"}) {
+		t.Errorf("returned with %d pending commands and log %v; initialized: %v", len(pending), log, initialized)
+	}
+}
+
+// fakeOpacityWindow emulates a window whose opacity can only be changed if a compositor is available.
+type fakeOpacityWindow struct {
+	compositor bool
+	opacity    float32
+	set        []float32
+}
+
+func (f *fakeOpacityWindow) GetOpacity() float32 {
+	if !f.compositor {
+		return 1
+	}
+	return f.opacity
+}
+func (f *fakeOpacityWindow) SetOpacity(opacity float32) {
+	f.set = append(f.set, opacity)
+	f.opacity = opacity
+}
+
+func TestOpacitySupported(t *testing.T) {
+	tests := []struct {
+		name       string
+		compositor bool
+		opacity    float32
+		want       bool
+		wantSet    []float32
+	}{
+		{"unsupported", false, 1, false, []float32{0.5, 1}},
+		{"opaque", true, 1, true, []float32{0.5, 1}},
+		{"half transparent", true, 0.55, true, []float32{0.25, 0.55}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			win := &fakeOpacityWindow{compositor: tt.compositor, opacity: tt.opacity}
+			if got := opacitySupported(win); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(win.set, tt.wantSet) {
+				t.Errorf("set opacities %v, want %v", win.set, tt.wantSet)
+			}
+		})
+	}
+}
+
+func TestCreateUtilityWindow(t *testing.T) {
+	var enqueued int
+	defer recordHints(&enqueued)()
+	WindowHint(Decorated, glfw.False) // Set by the user before, and restored afterwards.
+
+	share := new(glfw.Window)
+	hints := make(map[Hint]int)
+	var created bool
+	create := func(width, height int, title string, monitor *glfw.Monitor, s *glfw.Window) (*Window, error) {
+		created = true
+		if width != 1 || height != 1 || monitor != nil {
+			t.Errorf("created a %dx%d window on monitor %v, want a 1x1 window", width, height, monitor)
+		}
+		if s != share {
+			t.Error("context not shared")
+		}
+		want := map[Hint]int{Visible: glfw.False, Decorated: glfw.False, Focused: glfw.False, FocusOnShow: glfw.False}
+		if !reflect.DeepEqual(hints, want) {
+			t.Errorf("hints while creating: got %v, want %v", hints, want)
+		}
+		return new(Window), nil
+	}
+	w, err := createUtilityWindow(share, create, func(target Hint, value int) { hints[target] = value })
+	if w == nil || err != nil || !created {
+		t.Fatalf("got window %v and error %v", w, err)
+	}
+	want := map[Hint]int{Visible: glfw.True, Decorated: glfw.False, Focused: glfw.True, FocusOnShow: glfw.True}
+	if !reflect.DeepEqual(hints, want) {
+		t.Errorf("hints afterwards: got %v, want %v", hints, want)
+	}
+}
+
+func TestWithContext(t *testing.T) {
+	defer stubEnqueue()()
+	a, b := &Window{Window: new(glfw.Window)}, &Window{Window: new(glfw.Window)}
+	contexts, restore := stubContexts(map[*glfw.Window]string{a.Window: "a", b.Window: "b"})
+	defer restore()
+
+	tests := []struct {
+		name    string
+		current *Window
+		want    []string
+	}{
+		{"other context current", a, []string{"current b", "watcher current", "work b", "current a", "watcher current"}},
+		{"no context current", nil, []string{"current b", "watcher current", "work b", "detach", "watcher detach"}},
+		{"already current", b, []string{"work b"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			contexts.current, contexts.log = nil, nil
+			if tt.current != nil {
+				contexts.current = tt.current.Window
+			}
+			b.WithContext(func() {
+				if contexts.current != b.Window {
+					t.Error("context of b not current within fn")
+				}
+				contexts.log = append(contexts.log, "work b")
+			})
+			if !reflect.DeepEqual(contexts.log, tt.want) {
+				t.Errorf("got %v, want %v", contexts.log, tt.want)
+			}
+		})
+	}
+}
+
+func TestCreateWindowAt(t *testing.T) {
+	previous := linkedVersion
+	defer func() { linkedVersion = previous }()
+
+	for _, minor := range []int{3, 4} {
+		for _, visible := range []int{glfw.True, glfw.False} {
+			t.Run(fmt.Sprintf("glfw 3.%d visible %d", minor, visible), func(t *testing.T) {
+				var enqueued int
+				defer recordHints(&enqueued)()
+				WindowHint(Visible, visible)
+				linkedVersion = func() (int, int, int) { return 3, minor, 0 }
+
+				hints := make(map[Hint]int)
+				var hintsAtCreation map[Hint]int
+				create := func() (*Window, error) {
+					hintsAtCreation = make(map[Hint]int)
+					for hint, value := range hints {
+						hintsAtCreation[hint] = value
+					}
+					return new(Window), nil
+				}
+				win := &fakeNativeWindow{attribs: map[glfw.Hint]int{}}
+				native := func(*Window) nativeWindow { return win }
+				w, err := createWindowAt(100, 200, create, func(target Hint, value int) { hints[target] = value }, native)
+				if w == nil || err != nil {
+					t.Fatalf("got window %v and error %v", w, err)
+				}
+
+				if minor == 4 {
+					if hintsAtCreation[PositionX] != 100 || hintsAtCreation[PositionY] != 200 || len(win.shown) != 0 {
+						t.Errorf("created with hints %v and shown %d times, want position hints only", hintsAtCreation, len(win.shown))
+					}
+					if want := map[Hint]int{PositionX: AnyPosition, PositionY: AnyPosition}; !reflect.DeepEqual(hints, want) {
+						t.Errorf("hints afterwards: got %v, want %v", hints, want)
+					}
+					return
+				}
+				if want := map[Hint]int{Visible: glfw.False}; !reflect.DeepEqual(hintsAtCreation, want) {
+					t.Errorf("created with hints %v, want %v", hintsAtCreation, want)
+				}
+				if x, y := win.GetPos(); x != 100 || y != 200 {
+					t.Errorf("moved to (%d, %d), want (100, 200)", x, y)
+				}
+				if wantShown := visible == glfw.True; (len(win.shown) == 1) != wantShown {
+					t.Errorf("shown %d times, want shown: %v", len(win.shown), wantShown)
+				}
+				if hints[Visible] != visible {
+					t.Errorf("Visible hint restored to %d, want %d", hints[Visible], visible)
+				}
+			})
+		}
+	}
+}
+
+func TestCreateWindowWithOptionsConcurrently(t *testing.T) {
+	previousEnqueue, previousCreate := enqueue, createWindow
+	previousHint, previousHintString, previousDefault := windowHint, windowHintString, defaultWindowHints
+	defer func() {
+		enqueue, createWindow = previousEnqueue, previousCreate
+		windowHint, windowHintString, defaultWindowHints = previousHint, previousHintString, previousDefault
+	}()
+	forgetHintValues()
+
+	queue := make(chan func())
+	defer close(queue)
+	go func() {
+		for fn := range queue {
+			fn()
+		}
+	}()
+	enqueue = func(blocking bool, fn func()) {
+		if !blocking {
+			queue <- fn
+			return
+		}
+		done := make(chan struct{})
+		queue <- func() {
+			fn()
+			close(done)
+		}
+		<-done
+	}
+
+	// glfw's hint state, only accessed on the render thread.
+	hints := make(map[glfw.Hint]int)
+	windowHint = func(hint glfw.Hint, value int) { hints[hint] = value }
+	windowHintString = func(glfw.Hint, string) {}
+	defaultWindowHints = func() { hints = make(map[glfw.Hint]int) }
+	createWindow = func(_, _ int, title string, _ *glfw.Monitor, _ *glfw.Window) (*Window, error) {
+		return &Window{baseTitle: fmt.Sprintf("%s: %d samples", title, hints[glfw.Samples])}, nil
+	}
+
+	const n = 50
+	titles := make([]string, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			w, err := CreateWindowWithOptions(640, 480, fmt.Sprint(i), WindowOptions{Hints: map[Hint]int{Samples: i}})
+			if err != nil {
+				t.Errorf("window %d: %v", i, err)
+				return
+			}
+			titles[i] = w.baseTitle
+		}(i)
+	}
+	wg.Wait()
+
+	for i, title := range titles {
+		if want := fmt.Sprintf("%d: %d samples", i, i); title != want {
+			t.Errorf("got %q, want %q", title, want)
+		}
+	}
+	var remaining int
+	enqueue(true, func() { remaining = len(hints) })
+	if remaining != 0 {
+		t.Errorf("%d hints left applied after creating the windows", remaining)
+	}
+}
@@ -0,0 +1,41 @@
+// +build !js
+
+package glfw
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// SetDropCallbackFiltered sets a drop callback that only receives the dropped paths
+// whose file extension matches one of exts. Extensions are compared case-insensitively,
+// with or without the leading dot (".png" and "png" are equivalent).
+// If none of the dropped paths match, fn is not called.
+func (w *Window) SetDropCallbackFiltered(exts []string, fn DropCallback) {
+	w.SetDropCallback(filteredDropCallback(exts, fn))
+}
+
+// filteredDropCallback returns a drop callback that passes the paths matching exts on to fn, see SetDropCallbackFiltered.
+func filteredDropCallback(exts []string, fn DropCallback) DropCallback {
+	allowed := make(map[string]bool, len(exts))
+	for _, ext := range exts {
+		allowed["."+strings.TrimPrefix(strings.ToLower(ext), ".")] = true
+	}
+
+	return func(w *Window, names []string) {
+		if matching := filterByExtension(names, allowed); len(matching) > 0 {
+			fn(w, matching)
+		}
+	}
+}
+
+// filterByExtension returns the names whose lower-case extension is contained in allowed.
+func filterByExtension(names []string, allowed map[string]bool) []string {
+	var matching []string
+	for _, name := range names {
+		if allowed[strings.ToLower(filepath.Ext(name))] {
+			matching = append(matching, name)
+		}
+	}
+	return matching
+}
@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFilteredDropCallback(t *testing.T) {
+	tests := []struct {
+		name  string
+		exts  []string
+		names []string
+		want  []string // nil if fn must not be called.
+	}{
+		{"with dot", []string{".png"}, []string{"a.png", "b.jpg"}, []string{"a.png"}},
+		{"without dot", []string{"png"}, []string{"a.png", "b.jpg"}, []string{"a.png"}},
+		{"case-insensitive", []string{".PNG", "jpg"}, []string{"a.png", "b.JPG", "c.Png"}, []string{"a.png", "b.JPG", "c.Png"}},
+		{"directories", []string{"txt"}, []string{"dir.txt/file.go", "dir/file.TXT"}, []string{"dir/file.TXT"}},
+		{"no extension", []string{"txt"}, []string{"README", "txt"}, nil},
+		{"no match", []string{".png"}, []string{"a.jpg", "b.gif"}, nil},
+		{"no extensions", nil, []string{"a.png"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			called := false
+			cbfun := filteredDropCallback(tt.exts, func(_ *Window, names []string) {
+				called = true
+				got = names
+			})
+			cbfun(nil, tt.names)
+			if called != (tt.want != nil) {
+				t.Fatalf("called %v, want %v", called, tt.want != nil)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,47 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// Error codes that aren't exported by go-gl/glfw.
+const (
+	errorNotInitialized   glfw.ErrorCode = 0x00010001
+	errorNoCurrentContext glfw.ErrorCode = 0x00010002
+)
+
+// Error is an error reported by glfw.
+// It matches the sentinel error corresponding to its code when checked using errors.Is.
+type Error struct {
+	Code glfw.ErrorCode
+	Desc string
+}
+
+func (e *Error) Error() string {
+	return e.Code.String() + ": " + e.Desc
+}
+
+// Is reports whether the error code corresponds to the target sentinel error.
+func (e *Error) Is(target error) bool {
+	switch e.Code {
+	case errorNotInitialized:
+		return target == ErrNotInitialized
+	case errorNoCurrentContext:
+		return target == ErrNoWindow
+	case glfw.APIUnavailable:
+		return target == ErrContextCreationFailed || target == ErrUnsupported
+	case glfw.VersionUnavailable:
+		return target == ErrContextCreationFailed
+	case glfw.FormatUnavailable:
+		return target == ErrFormatUnavailable || target == ErrContextCreationFailed
+	}
+	return false
+}
+
+// wrapError converts errors returned by go-gl/glfw into *Error, so that they match the sentinel errors.
+func wrapError(err error) error {
+	if gerr, ok := err.(*glfw.Error); ok {
+		return &Error{Code: gerr.Code, Desc: gerr.Desc}
+	}
+	return err
+}
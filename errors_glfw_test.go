@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestErrorIs(t *testing.T) {
+	sentinels := []error{ErrNotInitialized, ErrNoWindow, ErrContextCreationFailed, ErrUnsupported, ErrFormatUnavailable}
+	tests := []struct {
+		name string
+		code glfw.ErrorCode
+		want []error // Matching sentinel errors.
+	}{
+		{"not initialized", errorNotInitialized, []error{ErrNotInitialized}},
+		{"no current context", errorNoCurrentContext, []error{ErrNoWindow}},
+		{"API unavailable", glfw.APIUnavailable, []error{ErrContextCreationFailed, ErrUnsupported}},
+		{"version unavailable", glfw.VersionUnavailable, []error{ErrContextCreationFailed}},
+		{"format unavailable", glfw.FormatUnavailable, []error{ErrContextCreationFailed, ErrFormatUnavailable}},
+		{"platform error", 0x00010008, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Wrapped like errors returned by this package.
+			err := fmt.Errorf("operation: %w", wrapError(&glfw.Error{Code: tt.code, Desc: "description"}))
+			for _, sentinel := range sentinels {
+				want := false
+				for _, w := range tt.want {
+					want = want || w == sentinel
+				}
+				if got := errors.Is(err, sentinel); got != want {
+					t.Errorf("errors.Is(%v): got %v, want %v", sentinel, got, want)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,316 @@
+// +build !js
+
+package glfw
+
+import (
+	"math"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// Event is an input event received by a window.
+//
+// It is one of KeyEvent, CharEvent, MouseButtonEvent, CursorPosEvent or ScrollEvent.
+type Event interface {
+	isEvent()
+}
+
+// KeyEvent is sent when a key is pressed, repeated or released.
+type KeyEvent struct {
+	Key      Key
+	Scancode int
+	Action   Action
+	Mods     ModifierKey
+}
+
+// CharEvent is sent when a unicode character is input.
+type CharEvent struct {
+	Char rune
+}
+
+// MouseButtonEvent is sent when a mouse button is pressed or released.
+type MouseButtonEvent struct {
+	Button MouseButton
+	Action Action
+	Mods   ModifierKey
+}
+
+// CursorPosEvent is sent when the cursor moves, in screen coordinates relative to the top-left corner of the content area.
+type CursorPosEvent struct {
+	X, Y float64
+}
+
+// ScrollEvent is sent when the user scrolls.
+type ScrollEvent struct {
+	XOff, YOff float64
+}
+
+func (KeyEvent) isEvent()         {}
+func (CharEvent) isEvent()        {}
+func (MouseButtonEvent) isEvent() {}
+func (CursorPosEvent) isEvent()   {}
+func (ScrollEvent) isEvent()      {}
+
+// installCallbacks registers the glfw input callbacks of the window.
+// They stay installed for the window's lifetime and route all events through dispatch.
+// Must be called on the render thread.
+func (w *Window) installCallbacks() {
+	w.Window.SetKeyCallback(func(_ *glfw.Window, key glfw.Key, scancode int, action glfw.Action, mods glfw.ModifierKey) {
+		w.dispatch(KeyEvent{Key(key), scancode, Action(action), ModifierKey(mods)})
+	})
+	w.Window.SetCharCallback(func(_ *glfw.Window, char rune) {
+		w.dispatch(CharEvent{char})
+	})
+	w.Window.SetMouseButtonCallback(func(_ *glfw.Window, button glfw.MouseButton, action glfw.Action, mods glfw.ModifierKey) {
+		w.dispatch(MouseButtonEvent{MouseButton(button), Action(action), ModifierKey(mods)})
+	})
+	w.Window.SetCursorPosCallback(func(_ *glfw.Window, xpos float64, ypos float64) {
+		w.dispatch(CursorPosEvent{xpos, ypos})
+	})
+	w.Window.SetScrollCallback(func(_ *glfw.Window, xoff float64, yoff float64) {
+		w.dispatch(ScrollEvent{xoff, yoff})
+	})
+	w.Window.SetCursorEnterCallback(func(_ *glfw.Window, entered bool) {
+		w.onCursorEnter(entered)
+	})
+
+	w.Window.SetSizeCallback(func(_ *glfw.Window, width int, height int) {
+		w.onSize(width, height)
+	})
+	w.Window.SetCloseCallback(func(_ *glfw.Window) {
+		w.onClose()
+	})
+	w.contentScale, _ = w.Window.GetContentScale()
+	w.fbRatio = framebufferRatio{x: 1, y: 1}
+	w.fbRatio.width, w.fbRatio.height = w.Window.GetSize()
+	w.fbRatio.fbWidth, w.fbRatio.fbHeight = w.Window.GetFramebufferSize()
+	w.fbRatio.update()
+	w.Window.SetContentScaleCallback(func(_ *glfw.Window, x float32, y float32) {
+		w.onContentScale(x, y)
+	})
+	w.Window.SetFramebufferSizeCallback(func(_ *glfw.Window, width int, height int) {
+		w.onFramebufferSize(width, height)
+	})
+
+	w.Window.SetRefreshCallback(func(_ *glfw.Window) {
+		w.onRefresh()
+	})
+
+	w.installStateCallbacks()
+}
+
+// dispatch updates the window's input state and delivers the event
+// to the registered callbacks and input channels.
+func (w *Window) dispatch(ev Event) {
+	original := ev
+	w.mu.Lock()
+	recorder := w.recorder
+	filter := w.eventFilter
+	var scancodeCallback ScancodeCallback
+	var duplicate bool
+	if e, ok := ev.(KeyEvent); ok {
+		scancodeCallback = w.scancodeCallback
+		if key, ok := w.keyRemap[e.Key]; ok {
+			e.Key = key
+			ev = e
+		}
+		duplicate = w.keyDebounce.drop(e, time.Now())
+	}
+	w.mu.Unlock()
+
+	if recorder != nil {
+		recorder.record(original)
+	}
+	if scancodeCallback != nil {
+		e := ev.(KeyEvent)
+		scancodeCallback(w, e.Scancode, e.Action)
+	}
+	if duplicate {
+		return
+	}
+	if filter != nil {
+		var keep bool
+		if ev, keep = filter(ev); !keep {
+			return
+		}
+	}
+
+	w.input.update(ev)
+	w.handleTitleBarDrag(ev)
+	w.handleInteractiveRegions(ev)
+
+	w.mu.Lock()
+	w.redrawOnEvent = true
+	keyCallback := w.keyCallback
+	charCallback := w.charCallback
+	mouseButtonCallback := w.mouseButtonCallback
+	cursorPosCallback := w.cursorPosCallback
+	scrollCallback := w.scrollCallback
+	switch e := ev.(type) {
+	case CursorPosEvent:
+		w.mouseDelta.move(e.X, e.Y)
+		if w.clampCursor && !w.cursorDisabled {
+			e.X = math.Max(0, math.Min(e.X, float64(w.fbRatio.width)))
+			e.Y = math.Max(0, math.Min(e.Y, float64(w.fbRatio.height)))
+			ev = e
+		}
+		if w.coalescedCursor.callback != nil {
+			w.coalescedCursor.pending = true
+			w.coalescedCursor.x, w.coalescedCursor.y = e.X, e.Y
+		}
+	case ScrollEvent:
+		if w.scrollScaleDPI {
+			// The cached horizontal scale is used for both axes, see SetScaleChangeCallback.
+			e.XOff *= float64(w.contentScale)
+			e.YOff *= float64(w.contentScale)
+		}
+		if w.naturalScroll {
+			e.YOff = -e.YOff
+		}
+		ev = e
+		if w.smoothScroll != nil {
+			w.smoothScroll.add(e.XOff, e.YOff)
+		}
+	}
+	channels := w.inputChannels
+	policy := w.overflowPolicy
+	observers := w.observers
+	w.mu.Unlock()
+
+	switch ev := ev.(type) {
+	case KeyEvent:
+		if keyCallback != nil {
+			keyCallback(w, ev.Key, ev.Scancode, ev.Action, ev.Mods)
+		}
+	case CharEvent:
+		if charCallback != nil {
+			charCallback(w, ev.Char)
+		}
+	case MouseButtonEvent:
+		if mouseButtonCallback != nil {
+			mouseButtonCallback(w, ev.Button, ev.Action, ev.Mods)
+		}
+	case CursorPosEvent:
+		if cursorPosCallback != nil {
+			cursorPosCallback(w, ev.X, ev.Y)
+		}
+	case ScrollEvent:
+		if scrollCallback != nil {
+			scrollCallback(w, ev.XOff, ev.YOff)
+		}
+	}
+
+	for _, o := range observers {
+		o.notifyInput(w, ev)
+	}
+
+	for _, c := range channels {
+		c.send(ev, policy)
+	}
+}
+
+func (w *Window) onFramebufferSize(width int, height int) {
+	w.mu.Lock()
+	w.redrawOnEvent = true
+	w.fbRatio.fbWidth, w.fbRatio.fbHeight = width, height
+	w.fbRatio.update()
+	cbfun := w.framebufferSizeCallback
+	resizeRender := w.resizeRender
+	suppress := !w.rawFramebufferSize && (width == 0 || height == 0)
+	w.mu.Unlock()
+
+	if suppress {
+		return
+	}
+	if cbfun != nil {
+		cbfun(w, width, height)
+	}
+	if resizeRender != nil {
+		resizeRender(w)
+	}
+}
+
+// EmitInitialFramebufferSize calls the framebuffer size callback with the current framebuffer size.
+//
+// glfw only reports framebuffer size changes, not the initial size of a newly created window.
+// Calling this once after registering the callback allows renderers to handle the initial size and resizes in the same code path.
+// The callback is invoked on the render thread.
+func (w *Window) EmitInitialFramebufferSize() {
+	enqueue(false, func() {
+		w.emitFramebufferSize(w.Window)
+	})
+}
+
+// emitFramebufferSize reports the current framebuffer size of the native window like a framebuffer size event.
+// Must be called on the render thread.
+func (w *Window) emitFramebufferSize(win nativeWindow) {
+	w.onFramebufferSize(win.GetFramebufferSize())
+}
+
+func (w *Window) onRefresh() {
+	w.mu.Lock()
+	w.redrawOnEvent = true
+	cbfun := w.refreshCallback
+	redraw := w.continuousRefresh
+	resizeRender := w.resizeRender
+	for _, waiter := range w.refreshWaiters {
+		close(waiter)
+	}
+	w.refreshWaiters = nil
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w)
+	}
+	if redraw != nil {
+		redraw(w)
+	}
+	if resizeRender != nil {
+		resizeRender(w)
+	}
+}
+
+// SetContinuousRefresh sets a function that redraws the window whenever its contents need to be refreshed.
+//
+// On Windows, moving or resizing a window enters a modal loop that blocks event processing,
+// so regular rendering stops and the window turns blank. The same happens during live resizing on macOS.
+// glfw still delivers refresh events within these loops, which are used to call fn.
+// fn is called on the render thread, after the regular refresh callback. Passing nil removes it.
+//
+// On X11 and Wayland, there is no modal loop; fn is only called if the window content is damaged.
+func (w *Window) SetContinuousRefresh(fn func(w *Window)) {
+	w.mu.Lock()
+	w.continuousRefresh = fn
+	w.mu.Unlock()
+}
+
+// SetResizeRenderCallback sets a function that renders a frame while the window is being resized,
+// so that the content follows the new dimensions in real time instead of turning black or stale.
+//
+// Like the function set via SetContinuousRefresh, fn is called on refresh events, which glfw still delivers
+// within the modal resize loops of Windows and macOS. In addition, it is called after each framebuffer size change,
+// so a single resize step may call it more than once. fn is called on the render thread,
+// after the regular callbacks, and is expected to render and swap buffers. Passing nil removes it.
+//
+// On X11 and Wayland, resizing doesn't block event processing, so the regular render loop keeps running.
+func (w *Window) SetResizeRenderCallback(fn func(w *Window)) {
+	w.mu.Lock()
+	w.resizeRender = fn
+	w.mu.Unlock()
+}
+
+// EventFilter is the function signature for event filters.
+// It returns the event to deliver, and false if the event should be dropped.
+type EventFilter func(ev Event) (Event, bool)
+
+// SetEventFilter sets a filter that runs on every input event of the window before it is processed,
+// allowing events to be modified (like remapping keys) or dropped.
+//
+// Dropped events don't reach callbacks, input channels or the tracked input state.
+// The filter is called on the render thread. Passing nil removes the filter.
+func (w *Window) SetEventFilter(filter EventFilter) {
+	w.mu.Lock()
+	w.eventFilter = filter
+	w.mu.Unlock()
+}
@@ -0,0 +1,114 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+// drain returns the events buffered in the channel.
+func drain(events <-chan Event) []Event {
+	var drained []Event
+	for {
+		select {
+		case ev := <-events:
+			drained = append(drained, ev)
+		default:
+			return drained
+		}
+	}
+}
+
+func TestDispatchRoutesToWindowChannels(t *testing.T) {
+	a, b := new(Window), new(Window)
+	eventsA, unsubscribeA := a.InputChannel(8)
+	defer unsubscribeA()
+	eventsB, unsubscribeB := b.InputChannel(8)
+	defer unsubscribeB()
+	eventsA2, unsubscribeA2 := a.InputChannel(8)
+
+	a.dispatch(KeyEvent{Key: KeyA, Action: Press})
+	b.dispatch(CharEvent{Char: 'b'})
+	a.dispatch(CursorPosEvent{X: 1, Y: 2})
+	unsubscribeA2()
+	a.dispatch(ScrollEvent{YOff: 1})
+
+	wantA := []Event{KeyEvent{Key: KeyA, Action: Press}, CursorPosEvent{X: 1, Y: 2}, ScrollEvent{YOff: 1}}
+	if got := drain(eventsA); !reflect.DeepEqual(got, wantA) {
+		t.Errorf("window a: got %v, want %v", got, wantA)
+	}
+	if got, want := drain(eventsB), []Event{CharEvent{Char: 'b'}}; !reflect.DeepEqual(got, want) {
+		t.Errorf("window b: got %v, want %v", got, want)
+	}
+	if got, want := drain(eventsA2), wantA[:2]; !reflect.DeepEqual(got, want) {
+		t.Errorf("unsubscribed channel of window a: got %v, want %v", got, want)
+	}
+}
+
+func TestSetEventFilter(t *testing.T) {
+	w := new(Window)
+	log := logCallbacks(w)
+	events, unsubscribe := w.InputChannel(8)
+	defer unsubscribe()
+
+	w.SetEventFilter(func(ev Event) (Event, bool) {
+		switch ev := ev.(type) {
+		case KeyEvent:
+			if ev.Key == KeyQ {
+				return ev, false
+			}
+			if ev.Key == KeyW {
+				ev.Key = KeyUp
+				return ev, true
+			}
+		case MouseButtonEvent:
+			return ev, false
+		}
+		return ev, true
+	})
+
+	w.dispatch(KeyEvent{Key: KeyQ, Action: Press})
+	w.dispatch(KeyEvent{Key: KeyW, Action: Press})
+	w.dispatch(MouseButtonEvent{Button: MouseButtonLeft, Action: Press})
+	w.dispatch(CharEvent{Char: 'w'})
+
+	if w.KeyDown(KeyQ) || w.MouseButtonDown(MouseButtonLeft) {
+		t.Error("dropped events updated the input state")
+	}
+	if !w.KeyDown(KeyUp) || w.KeyDown(KeyW) {
+		t.Error("modified event wasn't tracked as modified")
+	}
+	if want := []string{"key 265 0 1 0", `char 'w'`}; !reflect.DeepEqual(*log, want) {
+		t.Errorf("got callbacks %q, want %q", *log, want)
+	}
+	want := []Event{KeyEvent{Key: KeyUp, Action: Press}, CharEvent{Char: 'w'}}
+	if got := drain(events); !reflect.DeepEqual(got, want) {
+		t.Errorf("input channel got %v, want %v", got, want)
+	}
+
+	w.SetEventFilter(nil)
+	w.dispatch(KeyEvent{Key: KeyQ, Action: Press})
+	if !w.KeyDown(KeyQ) {
+		t.Error("event dropped after removing the filter")
+	}
+}
+
+func TestContinuousRefresh(t *testing.T) {
+	w := new(Window)
+	var calls []string
+	w.SetRefreshCallback(func(*Window) { calls = append(calls, "refresh") })
+	w.SetContinuousRefresh(func(*Window) { calls = append(calls, "redraw") })
+
+	w.onRefresh()
+	if want := []string{"refresh", "redraw"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %v, want %v", calls, want)
+	}
+
+	calls = nil
+	w.SetContinuousRefresh(nil)
+	w.onRefresh()
+	if want := []string{"refresh"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("after removing the redraw function: got calls %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,128 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// RedrawPolicy defines when RunEventLoop calls the frame function.
+type RedrawPolicy int
+
+const (
+	// RedrawAlways renders a frame in every iteration, polling for events without blocking.
+	RedrawAlways RedrawPolicy = iota
+	// RedrawOnEvent renders a frame after input, resize or refresh events, or if requested via RequestRedraw.
+	// The loop waits for events while idle.
+	RedrawOnEvent
+	// RedrawOnRequest only renders a frame if requested via RequestRedraw. The loop waits for events while idle.
+	RedrawOnRequest
+)
+
+// SetRedrawPolicy defines when RunEventLoop renders frames. The default is RedrawAlways.
+//
+// Policies other than RedrawAlways let mostly static applications idle without rendering.
+// Note that per-frame bookkeeping like smooth scrolling only advances while the loop is running.
+// Smooth scrolling counts as event for RedrawOnEvent; with RedrawOnRequest, the smooth scroll callback must request redraws itself.
+func (w *Window) SetRedrawPolicy(policy RedrawPolicy) {
+	w.mu.Lock()
+	w.redrawPolicy = policy
+	w.mu.Unlock()
+
+	PostEmptyEvent() // Wake up a waiting event loop to apply the policy.
+}
+
+// RequestRedraw requests RunEventLoop to render a frame, waking it up if it is waiting for events.
+func (w *Window) RequestRedraw() {
+	w.mu.Lock()
+	w.redrawRequested = true
+	w.mu.Unlock()
+
+	PostEmptyEvent()
+}
+
+// RunEventLoop processes pending events and calls frame once per iteration,
+// until the window is flagged for closing.
+// Whether frame is called in an iteration depends on the redraw policy, see SetRedrawPolicy.
+//
+// Per-frame bookkeeping of the window, like smooth scrolling, is advanced
+// on the render thread right after events have been processed.
+func RunEventLoop(w *Window, frame func()) {
+	for {
+		if w.processEvents(w.eventTimeout()) {
+			return
+		}
+		if w.takeRedraw() {
+			frame()
+		}
+	}
+}
+
+// animationInterval is the maximum time RunEventLoop waits for events while smooth scrolling is in progress, in seconds.
+const animationInterval = 1.0 / 60
+
+// passthroughPollInterval is the maximum time RunEventLoop waits for events while mouse passthrough is enabled
+// by interactive regions, in seconds.
+const passthroughPollInterval = 1.0 / 20
+
+// eventTimeout returns how long RunEventLoop may wait for events, in seconds.
+// Zero means events are polled, a negative value means waiting without timeout.
+//
+// While smooth scroll offsets are pending, waiting is limited to animationInterval,
+// so that scrolling continues even if no further events arrive.
+// While passthrough is enabled by interactive regions, the window receives no cursor events,
+// so waiting is limited to passthroughPollInterval to notice when the cursor re-enters a region.
+func (w *Window) eventTimeout() float64 {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	switch {
+	case w.redrawDueLocked():
+		return 0
+	case w.smoothScroll != nil && w.smoothScroll.pending():
+		return animationInterval
+	case w.passthrough:
+		return passthroughPollInterval
+	default:
+		return -1
+	}
+}
+
+// processEvents processes pending events and advances the per-frame bookkeeping of the window.
+// timeout is interpreted as returned by eventTimeout. It reports whether the window is flagged for closing.
+func (w *Window) processEvents(timeout float64) (shouldClose bool) {
+	enqueueEvents(true, func() {
+		switch {
+		case timeout == 0:
+			glfw.PollEvents()
+		case timeout > 0:
+			glfw.WaitEventsTimeout(timeout)
+		default:
+			glfw.WaitEvents()
+		}
+		flushCoalescedEvents()
+		w.tickSmoothScroll(glfw.GetTime())
+		w.pollInteractiveRegions()
+		shouldClose = w.Window.ShouldClose()
+	})
+	return shouldClose
+}
+
+// redrawDueLocked reports whether the next frame should be rendered without waiting for events. w.mu must be held.
+func (w *Window) redrawDueLocked() bool {
+	switch w.redrawPolicy {
+	case RedrawOnEvent:
+		return w.redrawOnEvent || w.redrawRequested
+	case RedrawOnRequest:
+		return w.redrawRequested
+	default:
+		return true
+	}
+}
+
+// takeRedraw reports whether a frame should be rendered, and resets pending redraws.
+func (w *Window) takeRedraw() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	due := w.redrawDueLocked()
+	w.redrawOnEvent = false
+	w.redrawRequested = false
+	return due
+}
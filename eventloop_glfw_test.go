@@ -0,0 +1,82 @@
+// +build !js
+
+package glfw
+
+import "testing"
+
+func TestEventTimeout(t *testing.T) {
+	scrolling := func() *smoothScroll {
+		s := &smoothScroll{decay: 0.5, lastTick: -1}
+		s.add(0, 1)
+		return s
+	}
+	tests := []struct {
+		name   string
+		window *Window
+		want   float64
+	}{
+		{"redraw always", &Window{redrawPolicy: RedrawAlways}, 0},
+		{"on event, idle", &Window{redrawPolicy: RedrawOnEvent}, -1},
+		{"on event, after event", &Window{redrawPolicy: RedrawOnEvent, redrawOnEvent: true}, 0},
+		{"on request, after event", &Window{redrawPolicy: RedrawOnRequest, redrawOnEvent: true}, -1},
+		{"on request, requested", &Window{redrawPolicy: RedrawOnRequest, redrawRequested: true}, 0},
+		{"smooth scroll idle", &Window{redrawPolicy: RedrawOnEvent, smoothScroll: &smoothScroll{decay: 0.5}}, -1},
+		{"smooth scroll pending", &Window{redrawPolicy: RedrawOnEvent, smoothScroll: scrolling()}, animationInterval},
+		{"smooth scroll pending, on request", &Window{redrawPolicy: RedrawOnRequest, smoothScroll: scrolling()}, animationInterval},
+		{"passthrough", &Window{redrawPolicy: RedrawOnEvent, passthrough: true}, passthroughPollInterval},
+		{"passthrough and smooth scroll", &Window{redrawPolicy: RedrawOnEvent, passthrough: true, smoothScroll: scrolling()}, animationInterval},
+		{"passthrough, redraw due", &Window{redrawPolicy: RedrawAlways, passthrough: true}, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.window.eventTimeout(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRedrawPolicyFrames(t *testing.T) {
+	// Each iteration of the event loop is emulated by applying the step, and then calling takeRedraw.
+	steps := []string{"idle", "event", "idle", "request", "idle", "scroll", "scroll tick", "idle"}
+	tests := []struct {
+		name   string
+		policy RedrawPolicy
+		want   int // Number of frames rendered.
+	}{
+		{"always", RedrawAlways, len(steps)},
+		{"on event", RedrawOnEvent, 4}, // event, request, scroll and the smooth scroll tick.
+		{"on request", RedrawOnRequest, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Window{redrawPolicy: tt.policy}
+			w.smoothScroll = &smoothScroll{cbfun: func(*Window, float64, float64) {}, decay: 0.5, lastTick: -1}
+			now := 0.0
+
+			frames := 0
+			for _, step := range steps {
+				now += 1.0 / 60
+				switch step {
+				case "event":
+					w.onSize(800, 600)
+				case "request":
+					w.mu.Lock()
+					w.redrawRequested = true // Like RequestRedraw, without waking up the event loop.
+					w.mu.Unlock()
+				case "scroll":
+					w.dispatch(ScrollEvent{YOff: 1})
+					w.tickSmoothScroll(now)
+				case "scroll tick":
+					w.tickSmoothScroll(now)
+				}
+				if w.takeRedraw() {
+					frames++
+				}
+			}
+			if frames != tt.want {
+				t.Errorf("got %d frames, want %d", frames, tt.want)
+			}
+		})
+	}
+}
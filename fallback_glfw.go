@@ -0,0 +1,72 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// CreateWindowWithFallback creates a windowed mode window, retrying with relaxed hints if creation fails.
+//
+// The window is first created with the current hints.
+// On failure, the fallback hint sets are applied one after another, each on top of the previous ones,
+// until a window can be created. For example, a first fallback might set Samples to 0 and a second one
+// might additionally disable SRGBCapable.
+// Window.HintFallback reports which fallback was needed.
+//
+// The fallback hints are only applied while creating the window; afterwards, the hints set via WindowHint are restored.
+// If all attempts fail, the error of the last attempt is returned.
+func CreateWindowWithFallback(width, height int, title string, fallbacks []map[Hint]int) (*Window, error) {
+	if enqueue == nil {
+		return nil, fmt.Errorf("create window: %w", ErrNotInitialized)
+	}
+
+	var err error
+	var window *Window
+	enqueue(true, func() {
+		create := func() (*Window, error) {
+			return createWindow(width, height, title, nil, nil)
+		}
+		setHint := func(target Hint, value int) {
+			if target, ok := resolveHint(target); ok {
+				windowHint(glfw.Hint(target), value)
+			}
+		}
+		window, err = createWithFallback(fallbacks, create, setHint)
+		if len(fallbacks) > 0 {
+			restoreHints()
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create window: %w", err)
+	}
+	return window, nil
+}
+
+// createWithFallback calls create with the current hints, and then after applying each fallback using setHint,
+// until it succeeds. Must be called on the render thread.
+func createWithFallback(fallbacks []map[Hint]int, create func() (*Window, error), setHint func(Hint, int)) (*Window, error) {
+	w, err := create()
+	if err == nil || len(fallbacks) == 0 {
+		return w, err
+	}
+
+	for i, hints := range fallbacks {
+		for target, value := range hints {
+			setHint(target, value)
+		}
+		if w, err = create(); err == nil {
+			w.hintFallback = i
+			return w, nil
+		}
+	}
+	return nil, fmt.Errorf("all %d fallbacks failed: %w", len(fallbacks), err)
+}
+
+// HintFallback returns the index of the fallback hint set that CreateWindowWithFallback needed to create the window.
+// It returns -1 if the window was created with the original hints or by a different function.
+func (w *Window) HintFallback() int {
+	return w.hintFallback
+}
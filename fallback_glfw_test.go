@@ -0,0 +1,75 @@
+// +build !js
+
+package glfw
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestCreateWithFallback(t *testing.T) {
+	fallbacks := []map[Hint]int{
+		{Samples: 0},
+		{SRGBCapable: 0},
+	}
+	tests := []struct {
+		name         string
+		fallbacks    []map[Hint]int
+		succeedAt    int // Attempt that succeeds, -1 for none.
+		wantAttempts int
+		wantFallback int
+		wantHints    map[Hint]int // Hints set for the last attempt.
+		wantErr      string
+	}{
+		{"original hints", fallbacks, 0, 1, -1, map[Hint]int{}, ""},
+		{"first fallback", fallbacks, 1, 2, 0, map[Hint]int{Samples: 0}, ""},
+		{"cumulative fallbacks", fallbacks, 2, 3, 1, map[Hint]int{Samples: 0, SRGBCapable: 0}, ""},
+		{"all failed", fallbacks, -1, 3, 0, map[Hint]int{Samples: 0, SRGBCapable: 0}, "all 2 fallbacks failed: format unavailable"},
+		{"no fallbacks", nil, -1, 1, 0, map[Hint]int{}, "format unavailable"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			hints := make(map[Hint]int)
+			attempts := 0
+			create := func() (*Window, error) {
+				attempts++
+				if attempts-1 == tt.succeedAt {
+					return &Window{hintFallback: -1}, nil
+				}
+				return nil, ErrFormatUnavailable
+			}
+			w, err := createWithFallback(tt.fallbacks, create, func(target Hint, value int) { hints[target] = value })
+
+			if attempts != tt.wantAttempts {
+				t.Errorf("got %d attempts, want %d", attempts, tt.wantAttempts)
+			}
+			if !reflect.DeepEqual(hints, tt.wantHints) {
+				t.Errorf("hints: got %v, want %v", hints, tt.wantHints)
+			}
+			if tt.wantErr != "" {
+				if err == nil || err.Error() != tt.wantErr || !errors.Is(err, ErrFormatUnavailable) {
+					t.Errorf("got error %v, want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if w.HintFallback() != tt.wantFallback {
+				t.Errorf("fallback: got %d, want %d", w.HintFallback(), tt.wantFallback)
+			}
+		})
+	}
+}
+
+func TestCreateWindowWithFallbackNotInitialized(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	enqueue = nil
+
+	w, err := CreateWindowWithFallback(640, 480, "test", []map[Hint]int{{Samples: 0}})
+	if w != nil || !errors.Is(err, ErrNotInitialized) {
+		t.Errorf("got window %v and error %v, want ErrNotInitialized", w, err)
+	}
+}
@@ -0,0 +1,114 @@
+// +build !js
+
+package glfw
+
+import (
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+var (
+	lastFocusedMu sync.Mutex
+	lastFocused   *Window
+)
+
+// HasFocus reports whether the window has input focus.
+//
+// The focus is tracked by the focus callback, so reading it doesn't require a round-trip to the render thread.
+func (w *Window) HasFocus() bool {
+	return atomic.LoadInt32(&w.focused) == 1
+}
+
+// LastFocusedWindow returns the window that most recently gained input focus,
+// even if it has lost focus since then. It returns nil if no window has been focused,
+// or if the last focused window has been destroyed.
+func LastFocusedWindow() *Window {
+	lastFocusedMu.Lock()
+	defer lastFocusedMu.Unlock()
+	return lastFocused
+}
+
+// setFocused updates the tracked focus of the window.
+// Gaining focus ends pending attention requests. Must be called on the render thread.
+func (w *Window) setFocused(focused bool) {
+	if !focused {
+		atomic.StoreInt32(&w.focused, 0)
+		return
+	}
+	atomic.StoreInt32(&w.focused, 1)
+	w.stopAttention()
+	clearWindowAttention(w)
+
+	lastFocusedMu.Lock()
+	lastFocused = w
+	lastFocusedMu.Unlock()
+}
+
+// clearWindowAttention resets the platform's attention state of the window. Replaced by tests.
+var clearWindowAttention = clearAttention
+
+// forgetLastFocused stops tracking w as the last focused window, before it is destroyed.
+func forgetLastFocused(w *Window) {
+	lastFocusedMu.Lock()
+	defer lastFocusedMu.Unlock()
+	if lastFocused == w {
+		lastFocused = nil
+	}
+}
+
+// attentionInterval is the interval at which RequestAttentionUntilFocused repeats its request.
+const attentionInterval = 3 * time.Second
+
+// RequestAttention requests user attention to the window, like flashing its taskbar entry or bouncing the dock icon.
+// The exact behavior depends on the platform; it is ignored if the window has input focus.
+func (w *Window) RequestAttention() {
+	enqueue(false, w.Window.RequestAttention)
+}
+
+// RequestAttentionUntilFocused requests user attention to the window, and repeats the request periodically until the window gains focus.
+//
+// Platforms differ in how long a single request lasts: some flash or bounce only once, others keep the urgency until focused.
+// Repeated requests are stopped by the focus callback, and the urgency is cleared where the platform doesn't do so itself,
+// so the window stops asking for attention as soon as the user switches to it.
+// The call is ignored if the window already has input focus.
+func (w *Window) RequestAttentionUntilFocused() {
+	if w.HasFocus() {
+		return
+	}
+	w.mu.Lock()
+	if w.attentionTimer == nil {
+		w.attentionTimer = time.AfterFunc(attentionInterval, w.repeatAttention)
+	}
+	w.mu.Unlock()
+
+	w.RequestAttention()
+}
+
+// repeatAttention repeats the attention request, unless it has been stopped.
+func (w *Window) repeatAttention() {
+	w.mu.Lock()
+	if w.attentionTimer == nil { // Stopped by stopAttention.
+		w.mu.Unlock()
+		return
+	}
+	w.attentionTimer.Reset(attentionInterval)
+	w.mu.Unlock()
+
+	enqueue(false, func() {
+		if !isRegistered(w) { // Destroyed while the timer was firing.
+			return
+		}
+		w.Window.RequestAttention()
+	})
+}
+
+// stopAttention stops repeated attention requests started by RequestAttentionUntilFocused.
+func (w *Window) stopAttention() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.attentionTimer != nil {
+		w.attentionTimer.Stop()
+		w.attentionTimer = nil
+	}
+}
@@ -0,0 +1,69 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFocusTracking(t *testing.T) {
+	previous := clearWindowAttention
+	defer func() { clearWindowAttention = previous }()
+	var cleared []*Window
+	clearWindowAttention = func(w *Window) { cleared = append(cleared, w) }
+
+	a, b := new(Window), new(Window)
+	var events []bool
+	a.SetFocusCallback(func(_ *Window, focused bool) { events = append(events, focused) })
+
+	a.onFocus(true)
+	if !a.HasFocus() || b.HasFocus() || LastFocusedWindow() != a {
+		t.Error("a gained focus: not tracked")
+	}
+	a.onFocus(false)
+	b.onFocus(true)
+	if a.HasFocus() || !b.HasFocus() || LastFocusedWindow() != b {
+		t.Error("focus moved to b: not tracked")
+	}
+	b.onFocus(false)
+	if b.HasFocus() || LastFocusedWindow() != b {
+		t.Error("b lost focus: should remain the last focused window")
+	}
+
+	forgetLastFocused(b)
+	if LastFocusedWindow() != nil {
+		t.Error("destroyed window still reported as last focused")
+	}
+	if want := []bool{true, false}; !reflect.DeepEqual(events, want) {
+		t.Errorf("focus callback of a got %v, want %v", events, want)
+	}
+	if len(cleared) != 2 || cleared[0] != a || cleared[1] != b {
+		t.Errorf("attention cleared %d times, want once per gained focus", len(cleared))
+	}
+}
+
+func TestRequestAttentionUntilFocused(t *testing.T) {
+	previousEnqueue, previousClear := enqueue, clearWindowAttention
+	defer func() { enqueue, clearWindowAttention = previousEnqueue, previousClear }()
+	var requests, cleared int
+	enqueue = func(_ bool, fn func()) { requests++ } // Never call into glfw.
+	clearWindowAttention = func(*Window) { cleared++ }
+
+	w := new(Window)
+	w.RequestAttentionUntilFocused()
+	if requests != 1 || w.attentionTimer == nil {
+		t.Fatalf("got %d requests, repeating: %v; want 1 repeating request", requests, w.attentionTimer != nil)
+	}
+
+	w.onFocus(true)
+	if w.attentionTimer != nil || cleared != 1 {
+		t.Errorf("after gaining focus: repeating %v, cleared %d times; want stopped and cleared once", w.attentionTimer != nil, cleared)
+	}
+	w.repeatAttention() // A timer firing concurrently with the focus change.
+	w.RequestAttentionUntilFocused()
+	if requests != 1 {
+		t.Errorf("got %d requests while focused, want none", requests-1)
+	}
+	forgetLastFocused(w)
+}
@@ -0,0 +1,94 @@
+// +build !js
+
+package glfw
+
+import (
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// SetFrameLimit caps the frame rate enforced by LimitFrame. Zero or negative values remove the limit.
+//
+// This is intended for applications that disabled vsync via SwapInterval(0).
+func (w *Window) SetFrameLimit(fps int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if fps <= 0 {
+		w.frameInterval = 0
+		return
+	}
+	w.frameInterval = 1 / float64(fps)
+	w.nextFrame = 0
+}
+
+// LimitFrame sleeps until the next frame is due according to the frame limit.
+// It should be called once per frame, after SwapBuffers.
+//
+// Time spent on rendering is taken into account, so the sleep duration shrinks as frames become more expensive.
+// Frames are paced using the glfw timer, without busy-waiting.
+func (w *Window) LimitFrame() {
+	w.mu.Lock()
+	sleep := frameSleep(&w.nextFrame, w.frameInterval, GetTime())
+	w.mu.Unlock()
+
+	if sleep > 0 {
+		time.Sleep(sleep)
+	}
+}
+
+// frameSleep returns how long to sleep until the next frame is due, and advances the frame deadline.
+// If the application falls behind by more than a frame, the schedule is reset instead of trying to catch up.
+func frameSleep(nextFrame *float64, interval float64, now float64) time.Duration {
+	if interval <= 0 {
+		return 0
+	}
+	if *nextFrame == 0 || now-*nextFrame > interval {
+		*nextFrame = now + interval
+		return 0
+	}
+	due := *nextFrame
+	*nextFrame += interval
+	if now >= due {
+		return 0
+	}
+	return time.Duration((due - now) * float64(time.Second))
+}
+
+// MeasureRefreshRate measures the effective rate, in Hz, at which the window's buffers are swapped,
+// by timing the given number of consecutive swaps on the render thread using the glfw timer.
+//
+// It detects whether vsync is effectively enabled, since drivers may override the swap interval:
+// a rate close to the monitor's refresh rate (see RefreshRate) indicates that swaps are synchronized.
+// The window's context is made current for the measurement, and the previously current context is restored afterwards.
+// The swaps present whatever the back buffer contains.
+//
+// The call blocks the caller and the render thread for the duration of the measurement,
+// roughly samples divided by the refresh rate. Returns 0 if samples is not positive.
+func (w *Window) MeasureRefreshRate(samples int) float64 {
+	if samples <= 0 {
+		return 0
+	}
+	var rate float64
+	enqueue(true, func() {
+		w.withCurrentContext(func() {
+			rate = swapRate(samples, glfw.GetTime, w.Window.SwapBuffers)
+		})
+	})
+	return rate
+}
+
+// swapRate returns the number of swaps per second, measured over the given number of swaps.
+// An initial swap aligns the measurement with the swap schedule.
+func swapRate(samples int, now func() float64, swap func()) float64 {
+	swap()
+	start := now()
+	for i := 0; i < samples; i++ {
+		swap()
+	}
+	elapsed := now() - start
+	if elapsed <= 0 {
+		return 0
+	}
+	return float64(samples) / elapsed
+}
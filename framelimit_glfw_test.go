@@ -0,0 +1,68 @@
+// +build !js
+
+package glfw
+
+import (
+	"math"
+	"testing"
+	"time"
+)
+
+func TestFrameSleep(t *testing.T) {
+	tests := []struct {
+		name          string
+		nextFrame     float64
+		interval      float64
+		now           float64
+		want          time.Duration
+		wantNextFrame float64
+	}{
+		{"unlimited", 5, 0, 1, 0, 5},
+		{"first frame", 0, 0.5, 1, 0, 1.5},
+		{"early", 2, 0.5, 1.75, 250 * time.Millisecond, 2.5},
+		{"on time", 2, 0.5, 2, 0, 2.5},
+		{"slightly late", 2, 0.5, 2.25, 0, 2.5},
+		{"behind by more than a frame", 2, 0.5, 3, 0, 3.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nextFrame := tt.nextFrame
+			if got := frameSleep(&nextFrame, tt.interval, tt.now); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if nextFrame != tt.wantNextFrame {
+				t.Errorf("next frame: got %v, want %v", nextFrame, tt.wantNextFrame)
+			}
+		})
+	}
+}
+
+func TestSwapRate(t *testing.T) {
+	tests := []struct {
+		name     string
+		samples  int
+		swapTime float64 // Seconds per swap.
+		want     float64
+	}{
+		{"60 Hz", 10, 1.0 / 60, 60},
+		{"144 Hz", 20, 1.0 / 144, 144},
+		{"no samples", 0, 1.0 / 60, 0},
+		{"no elapsed time", 10, 0, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var clock float64
+			swaps := 0
+			got := swapRate(tt.samples, func() float64 { return clock }, func() {
+				swaps++
+				clock += tt.swapTime
+			})
+			if math.Abs(got-tt.want) > 1e-6 {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if swaps != tt.samples+1 {
+				t.Errorf("got %d swaps, want %d", swaps, tt.samples+1)
+			}
+		})
+	}
+}
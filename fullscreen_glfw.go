@@ -0,0 +1,214 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// SetMonitor sets the monitor that the window uses for full screen mode or,
+// if the monitor is nil, makes it windowed mode.
+//
+// When setting a monitor, this function updates the width, height and refresh
+// rate of the desired video mode and switches to the video mode closest to it.
+// The window position is ignored when setting a monitor.
+//
+// When the monitor is nil, the position, width and height are used to place the
+// window client area. The refresh rate is ignored when no monitor is specified.
+// If you only wish to update the resolution of a full screen window or the size of
+// a windowed mode window, see SetSize.
+//
+// When a windowed mode window becomes full screen, its position and size are saved.
+// When switching back to windowed mode, each of xpos, ypos, width and height that is DontCare
+// is replaced by the saved value, restoring the original placement.
+//
+// The cursor mode is preserved across the switch. See SetFullscreen for details.
+func (w *Window) SetMonitor(monitor *Monitor, xpos, ypos, width, height, refreshRate int) {
+	var m *glfw.Monitor
+	if monitor != nil {
+		m = monitor.Monitor
+	}
+	enqueue(false, func() {
+		w.setMonitor(m, xpos, ypos, width, height, refreshRate)
+	})
+}
+
+// SetFullscreen makes the window full screen on the given monitor, using the monitor's current video mode.
+//
+// The cursor mode is captured before the switch and reapplied afterwards.
+// Some platforms (notably X11 window managers and Windows) reset a disabled cursor to normal
+// when the window is moved to or from a monitor, which breaks mouse-look controls.
+//
+// The call is ignored if monitor is nil, which GetPrimaryMonitor returns if no monitor is connected.
+func (w *Window) SetFullscreen(monitor *Monitor) {
+	if monitor == nil {
+		return
+	}
+	enqueue(false, func() {
+		mode := monitor.Monitor.GetVideoMode()
+		if mode == nil { // Disconnected in the meantime.
+			return
+		}
+		w.setMonitor(monitor.Monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+	})
+}
+
+// SetWindowed makes the window windowed, placing its content area at the given position and size in screen coordinates.
+// Pass DontCare for all values to restore the position and size the window had before it became full screen.
+// The cursor mode is preserved, see SetFullscreen.
+func (w *Window) SetWindowed(xpos, ypos, width, height int) {
+	enqueue(false, func() {
+		w.setMonitor(nil, xpos, ypos, width, height, 0)
+	})
+}
+
+// setMonitor switches monitors while preserving the cursor mode and windowed geometry.
+// Must be called on the render thread.
+func (w *Window) setMonitor(m *glfw.Monitor, xpos, ypos, width, height, refreshRate int) {
+	w.resetMouseDelta()
+	w.switchMonitor(w.Window, m, xpos, ypos, width, height, refreshRate)
+}
+
+// nativeWindow is the part of glfw.Window used to switch between full screen and windowed mode.
+type nativeWindow interface {
+	GetMonitor() *glfw.Monitor
+	SetMonitor(monitor *glfw.Monitor, xpos, ypos, width, height, refreshRate int)
+	GetPos() (x, y int)
+	SetPos(xpos, ypos int)
+	GetSize() (width, height int)
+	GetFramebufferSize() (width, height int)
+	SetSize(width, height int)
+	GetAttrib(attrib glfw.Hint) int
+	SetAttrib(attrib glfw.Hint, value int)
+	Restore()
+	Maximize()
+	Show()
+	GetInputMode(mode glfw.InputMode) int
+	SetInputMode(mode glfw.InputMode, value int)
+}
+
+// switchMonitor implements setMonitor for the given native window.
+//
+// If a window that was created full screen is made windowed with DontCare values, there is no saved geometry.
+// Half of the full screen size is used instead, centered on the monitor.
+// A borderless full screen window leaves borderless mode before becoming full screen,
+// so that its original geometry is saved and ExitBorderlessFullscreen doesn't apply stale state later on.
+func (w *Window) switchMonitor(win nativeWindow, m *glfw.Monitor, xpos, ypos, width, height, refreshRate int) {
+	if m != nil {
+		w.exitBorderless(win)
+	}
+	windowed := win.GetMonitor() == nil
+	switch {
+	case m != nil && windowed:
+		x, y := win.GetPos()
+		sizeX, sizeY := win.GetSize()
+		w.windowedGeometry = &[4]int{x, y, sizeX, sizeY}
+	case m == nil && !windowed:
+		saved := w.windowedGeometry
+		if saved == nil {
+			x, y := win.GetPos()
+			sizeX, sizeY := win.GetSize()
+			saved = &[4]int{x + sizeX/4, y + sizeY/4, sizeX / 2, sizeY / 2}
+		}
+		geometry := []*int{&xpos, &ypos, &width, &height}
+		for i, v := range geometry {
+			if *v == DontCare {
+				*v = saved[i]
+			}
+		}
+	}
+
+	cursorMode := win.GetInputMode(glfw.CursorMode)
+	win.SetMonitor(m, xpos, ypos, width, height, refreshRate)
+	if win.GetInputMode(glfw.CursorMode) != cursorMode {
+		win.SetInputMode(glfw.CursorMode, cursorMode)
+	}
+}
+
+// borderlessState is saved when entering borderless full screen mode, to be restored when leaving it.
+type borderlessState struct {
+	geometry             [4]int // x, y, width, height
+	decorated, resizable int
+	maximized            bool
+}
+
+// SetBorderlessFullscreen makes the window cover the given monitor as an undecorated, non-resizable windowed mode window,
+// also known as windowed full screen. Unlike SetFullscreen, the video mode is not changed, which makes switching
+// to other windows fast.
+//
+// The window's position, size, decoration and resizability are saved and restored by ExitBorderlessFullscreen.
+// Exclusive full screen windows are made windowed first. The call is ignored if monitor is nil.
+func (w *Window) SetBorderlessFullscreen(monitor *Monitor) {
+	if monitor == nil {
+		return
+	}
+	enqueue(false, func() {
+		mode := monitor.Monitor.GetVideoMode()
+		if mode == nil {
+			return
+		}
+		x, y := monitor.Monitor.GetPos()
+		if w.Window.GetMonitor() != nil {
+			w.resetMouseDelta()
+		}
+		w.enterBorderless(w.Window, x, y, mode.Width, mode.Height)
+	})
+}
+
+// enterBorderless makes the window cover the given monitor area. Must be called on the render thread.
+func (w *Window) enterBorderless(win nativeWindow, x, y, width, height int) {
+	if win.GetMonitor() != nil {
+		w.switchMonitor(win, nil, DontCare, DontCare, DontCare, DontCare, 0)
+	}
+
+	if w.borderless == nil {
+		state := &borderlessState{
+			decorated: win.GetAttrib(glfw.Decorated),
+			resizable: win.GetAttrib(glfw.Resizable),
+			maximized: win.GetAttrib(glfw.Maximized) == glfw.True,
+		}
+		if state.maximized {
+			win.Restore()
+		}
+		state.geometry[0], state.geometry[1] = win.GetPos()
+		state.geometry[2], state.geometry[3] = win.GetSize()
+		w.borderless = state
+	}
+
+	win.SetAttrib(glfw.Decorated, glfw.False)
+	win.SetAttrib(glfw.Resizable, glfw.False)
+	win.SetPos(x, y)
+	win.SetSize(width, height)
+}
+
+// ExitBorderlessFullscreen restores the window state saved by SetBorderlessFullscreen.
+// The call is ignored if the window is not in borderless full screen mode.
+func (w *Window) ExitBorderlessFullscreen() {
+	enqueue(false, func() {
+		w.exitBorderless(w.Window)
+	})
+}
+
+// exitBorderless restores the window state saved by enterBorderless, if any. Must be called on the render thread.
+func (w *Window) exitBorderless(win nativeWindow) {
+	state := w.borderless
+	if state == nil {
+		return
+	}
+	w.borderless = nil
+
+	win.SetAttrib(glfw.Decorated, state.decorated)
+	win.SetAttrib(glfw.Resizable, state.resizable)
+	win.SetPos(state.geometry[0], state.geometry[1])
+	win.SetSize(state.geometry[2], state.geometry[3])
+	if state.maximized {
+		win.Maximize()
+	}
+}
+
+// IsBorderlessFullscreen reports whether the window is in borderless full screen mode.
+func (w *Window) IsBorderlessFullscreen() bool {
+	var borderless bool
+	enqueue(true, func() {
+		borderless = w.borderless != nil
+	})
+	return borderless
+}
@@ -0,0 +1,196 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// fakeNativeWindow emulates a window manager that resets the cursor mode when switching monitors.
+type fakeNativeWindow struct {
+	monitor       *glfw.Monitor
+	x, y          int
+	width, height int
+	scale         int // Framebuffer pixels per screen coordinate; 1 if zero.
+	cursorMode    int
+	inputModes    map[glfw.InputMode]int // Input modes other than the cursor mode.
+	attribs       map[glfw.Hint]int
+	shown         []map[glfw.Hint]int // Attributes at each call to Show.
+}
+
+func (f *fakeNativeWindow) GetMonitor() *glfw.Monitor { return f.monitor }
+func (f *fakeNativeWindow) SetMonitor(monitor *glfw.Monitor, xpos, ypos, width, height, refreshRate int) {
+	if width < 0 || height < 0 {
+		panic("invalid size") // Like GLFW_INVALID_VALUE, reported as panic by go-gl/glfw.
+	}
+	f.monitor = monitor
+	if monitor == nil {
+		f.x, f.y = xpos, ypos
+	} else {
+		f.x, f.y = 0, 0
+	}
+	f.width, f.height = width, height
+	f.cursorMode = glfw.CursorNormal
+}
+func (f *fakeNativeWindow) SetPos(x, y int)                   { f.x, f.y = x, y }
+func (f *fakeNativeWindow) SetSize(width, height int)         { f.width, f.height = width, height }
+func (f *fakeNativeWindow) GetAttrib(attrib glfw.Hint) int    { return f.attribs[attrib] }
+func (f *fakeNativeWindow) SetAttrib(attrib glfw.Hint, v int) { f.attribs[attrib] = v }
+func (f *fakeNativeWindow) Restore()                          { f.attribs[glfw.Maximized] = glfw.False }
+func (f *fakeNativeWindow) Maximize()                         { f.attribs[glfw.Maximized] = glfw.True }
+func (f *fakeNativeWindow) GetPos() (int, int)                { return f.x, f.y }
+func (f *fakeNativeWindow) GetSize() (int, int)               { return f.width, f.height }
+func (f *fakeNativeWindow) geometry() [4]int                  { return [4]int{f.x, f.y, f.width, f.height} }
+func (f *fakeNativeWindow) GetFramebufferSize() (int, int) {
+	if f.scale == 0 {
+		return f.width, f.height
+	}
+	return f.width * f.scale, f.height * f.scale
+}
+func (f *fakeNativeWindow) GetInputMode(mode glfw.InputMode) int {
+	if mode == glfw.CursorMode {
+		return f.cursorMode
+	}
+	return f.inputModes[mode]
+}
+func (f *fakeNativeWindow) SetInputMode(mode glfw.InputMode, value int) {
+	if mode == glfw.CursorMode {
+		f.cursorMode = value
+		return
+	}
+	if f.inputModes == nil {
+		f.inputModes = make(map[glfw.InputMode]int)
+	}
+	f.inputModes[mode] = value
+}
+func (f *fakeNativeWindow) Show() {
+	attribs := make(map[glfw.Hint]int, len(f.attribs))
+	for attrib, value := range f.attribs {
+		attribs[attrib] = value
+	}
+	f.shown = append(f.shown, attribs)
+}
+
+func TestSwitchMonitor(t *testing.T) {
+	monitor := new(glfw.Monitor)
+	tests := []struct {
+		name         string
+		initial      fakeNativeWindow
+		windowed     [4]int // Passed to SetWindowed.
+		wantWindowed [4]int
+	}{
+		{"restores saved geometry", fakeNativeWindow{x: 100, y: 50, width: 800, height: 600}, [4]int{DontCare, DontCare, DontCare, DontCare}, [4]int{100, 50, 800, 600}},
+		{"explicit geometry", fakeNativeWindow{x: 100, y: 50, width: 800, height: 600}, [4]int{10, 20, 640, 480}, [4]int{10, 20, 640, 480}},
+		{"partially saved geometry", fakeNativeWindow{x: 100, y: 50, width: 800, height: 600}, [4]int{DontCare, DontCare, 640, 480}, [4]int{100, 50, 640, 480}},
+		{"created full screen", fakeNativeWindow{monitor: monitor, width: 1920, height: 1080}, [4]int{DontCare, DontCare, DontCare, DontCare}, [4]int{480, 270, 960, 540}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(Window)
+			win := tt.initial
+			win.cursorMode = glfw.CursorDisabled
+
+			if win.monitor == nil {
+				w.switchMonitor(&win, monitor, 0, 0, 1920, 1080, 60)
+				if win.monitor != monitor || win.geometry() != [4]int{0, 0, 1920, 1080} {
+					t.Fatalf("full screen: got monitor %p, geometry %v", win.monitor, win.geometry())
+				}
+			}
+			w.switchMonitor(&win, nil, tt.windowed[0], tt.windowed[1], tt.windowed[2], tt.windowed[3], 0)
+			if win.monitor != nil {
+				t.Fatal("window is still full screen")
+			}
+			if win.geometry() != tt.wantWindowed {
+				t.Errorf("windowed: got %v, want %v", win.geometry(), tt.wantWindowed)
+			}
+			if win.cursorMode != glfw.CursorDisabled {
+				t.Errorf("cursor mode was not preserved")
+			}
+		})
+	}
+}
+
+func TestBorderlessFullscreen(t *testing.T) {
+	monitor := new(glfw.Monitor)
+	windowed := func() fakeNativeWindow {
+		return fakeNativeWindow{x: 100, y: 50, width: 800, height: 600, attribs: map[glfw.Hint]int{
+			glfw.Decorated: glfw.True, glfw.Resizable: glfw.True, glfw.Maximized: glfw.False,
+		}}
+	}
+	covers := func(win *fakeNativeWindow) bool {
+		return win.monitor == nil && win.geometry() == [4]int{1920, 0, 2560, 1440} &&
+			win.attribs[glfw.Decorated] == glfw.False && win.attribs[glfw.Resizable] == glfw.False
+	}
+	restored := func(win *fakeNativeWindow) bool {
+		return win.monitor == nil && win.geometry() == [4]int{100, 50, 800, 600} &&
+			win.attribs[glfw.Decorated] == glfw.True && win.attribs[glfw.Resizable] == glfw.True
+	}
+
+	t.Run("enter and exit", func(t *testing.T) {
+		w, win := new(Window), windowed()
+		w.enterBorderless(&win, 1920, 0, 2560, 1440)
+		if !covers(&win) {
+			t.Fatalf("doesn't cover the monitor: %v, attribs %v", win.geometry(), win.attribs)
+		}
+		w.enterBorderless(&win, 1920, 0, 2560, 1440) // Repeated calls keep the original state.
+		w.exitBorderless(&win)
+		if !restored(&win) || w.borderless != nil {
+			t.Errorf("not restored: %v, attribs %v", win.geometry(), win.attribs)
+		}
+	})
+	t.Run("maximized", func(t *testing.T) {
+		w, win := new(Window), windowed()
+		win.attribs[glfw.Maximized] = glfw.True
+		w.enterBorderless(&win, 1920, 0, 2560, 1440)
+		if win.attribs[glfw.Maximized] != glfw.False {
+			t.Error("window was not restored before covering the monitor")
+		}
+		w.exitBorderless(&win)
+		if win.attribs[glfw.Maximized] != glfw.True {
+			t.Error("window was not maximized again")
+		}
+	})
+	t.Run("exclusive full screen while borderless", func(t *testing.T) {
+		w, win := new(Window), windowed()
+		w.enterBorderless(&win, 1920, 0, 2560, 1440)
+		w.switchMonitor(&win, monitor, 0, 0, 2560, 1440, 60)
+		if w.borderless != nil {
+			t.Fatal("borderless state was not cleared")
+		}
+		w.exitBorderless(&win) // Must not apply stale state over the exclusive mode.
+		if win.monitor != monitor || win.geometry() != [4]int{0, 0, 2560, 1440} {
+			t.Fatalf("exclusive mode was changed: %v", win.geometry())
+		}
+		w.switchMonitor(&win, nil, DontCare, DontCare, DontCare, DontCare, 0)
+		if !restored(&win) {
+			t.Errorf("not restored: %v, attribs %v", win.geometry(), win.attribs)
+		}
+	})
+}
+
+func TestSwitchMonitorCursorMode(t *testing.T) {
+	monitor := new(glfw.Monitor)
+	for _, mode := range []int{glfw.CursorNormal, glfw.CursorHidden, glfw.CursorDisabled} {
+		w := new(Window)
+		win := fakeNativeWindow{width: 800, height: 600, cursorMode: mode}
+		w.switchMonitor(&win, monitor, 0, 0, 1920, 1080, 60)
+		if win.cursorMode != mode {
+			t.Errorf("cursor mode %#x: got %#x in full screen", mode, win.cursorMode)
+		}
+		w.switchMonitor(&win, nil, DontCare, DontCare, DontCare, DontCare, 0)
+		if win.cursorMode != mode {
+			t.Errorf("cursor mode %#x: got %#x after returning to windowed mode", mode, win.cursorMode)
+		}
+	}
+
+	var enqueued int
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	enqueue = func(bool, func()) { enqueued++ }
+	new(Window).SetFullscreen(nil)
+	if enqueued != 0 {
+		t.Error("SetFullscreen without monitor enqueued a command")
+	}
+}
@@ -0,0 +1,93 @@
+// +build !js
+
+package glfw
+
+import "time"
+
+// Defaults of GameLoopConfig.
+const (
+	defaultTimestep     = time.Second / 60
+	defaultMaxFrameTime = 250 * time.Millisecond
+)
+
+// GameLoopConfig configures RunGameLoop.
+type GameLoopConfig struct {
+	// Update advances the simulation by dt seconds, which is always the fixed timestep.
+	Update func(dt float64)
+	// Render renders a frame. alpha in [0, 1) is the fraction of a timestep that has not been simulated yet,
+	// for interpolating between the previous and current simulation state.
+	Render func(alpha float64)
+
+	// FixedTimestep is the simulation timestep. Defaults to 1/60 seconds.
+	FixedTimestep time.Duration
+	// MaxFrameTime caps the time simulated per frame. Defaults to 250 milliseconds.
+	// If a frame takes longer, like after a stall, the simulation slows down instead of trying to catch up,
+	// which would make the following frames even slower.
+	MaxFrameTime time.Duration
+}
+
+// RunGameLoop runs a fixed timestep game loop until the window is flagged for closing.
+//
+// Each iteration polls events, calls Update as often as needed to catch up with the elapsed time,
+// and then calls Render once. Time is measured using the glfw timer.
+// Like RunEventLoop, the per-frame bookkeeping of the window is advanced after events have been processed.
+// The redraw policy is ignored; a frame is rendered in every iteration.
+func RunGameLoop(w *Window, cfg GameLoopConfig) {
+	timestep := cfg.FixedTimestep
+	if timestep <= 0 {
+		timestep = defaultTimestep
+	}
+	maxFrameTime := cfg.MaxFrameTime
+	if maxFrameTime <= 0 {
+		maxFrameTime = defaultMaxFrameTime
+	}
+	loop := fixedTimestep{step: timestep.Seconds(), maxFrameTime: maxFrameTime.Seconds()}
+
+	previous := GetTime()
+	for {
+		if w.processEvents(0) {
+			return
+		}
+
+		now := GetTime()
+		steps := loop.advance(now - previous)
+		previous = now
+
+		if cfg.Update != nil {
+			for i := 0; i < steps; i++ {
+				cfg.Update(loop.step)
+			}
+		}
+		if cfg.Render != nil {
+			cfg.Render(loop.alpha())
+		}
+	}
+}
+
+// fixedTimestep accumulates elapsed time and divides it into fixed simulation steps.
+type fixedTimestep struct {
+	step         float64 // In seconds.
+	maxFrameTime float64 // In seconds.
+	accumulator  float64 // Elapsed time not simulated yet, in seconds.
+}
+
+// advance adds the elapsed time, capped to the maximum frame time, and returns the number of steps to simulate.
+func (t *fixedTimestep) advance(elapsed float64) int {
+	if elapsed > t.maxFrameTime {
+		elapsed = t.maxFrameTime
+	}
+	if elapsed > 0 {
+		t.accumulator += elapsed
+	}
+	steps := 0
+	for t.accumulator >= t.step {
+		t.accumulator -= t.step
+		steps++
+	}
+	return steps
+}
+
+// alpha returns the fraction of a step that has not been simulated yet.
+func (t *fixedTimestep) alpha() float64 {
+	return t.accumulator / t.step
+}
@@ -0,0 +1,35 @@
+// +build !js
+
+package glfw
+
+import (
+	"math"
+	"testing"
+)
+
+func TestFixedTimestepAdvance(t *testing.T) {
+	tests := []struct {
+		name      string
+		elapsed   []float64 // Elapsed time per frame.
+		wantSteps []int
+		wantAlpha float64 // After the last frame.
+	}{
+		{"exact steps", []float64{0.1, 0.2}, []int{1, 2}, 0},
+		{"accumulates", []float64{0.04, 0.04, 0.04}, []int{0, 0, 1}, 0.2},
+		{"capped frame time", []float64{10}, []int{5}, 0},
+		{"negative elapsed time", []float64{0.05, -1}, []int{0, 0}, 0.5},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			loop := fixedTimestep{step: 0.1, maxFrameTime: 0.5}
+			for i, elapsed := range tt.elapsed {
+				if got := loop.advance(elapsed); got != tt.wantSteps[i] {
+					t.Errorf("frame %d: got %d steps, want %d", i, got, tt.wantSteps[i])
+				}
+			}
+			if got := loop.alpha(); math.Abs(got-tt.wantAlpha) > 1e-9 {
+				t.Errorf("alpha: got %v, want %v", got, tt.wantAlpha)
+			}
+		})
+	}
+}
@@ -0,0 +1,212 @@
+// +build !js
+
+package glfw
+
+import (
+	"math"
+	"sync"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// GammaRamp describes the gamma ramp for a monitor.
+type GammaRamp struct {
+	Red   []uint16 // A slice of value describing the response of the red channel.
+	Green []uint16 // A slice of value describing the response of the green channel.
+	Blue  []uint16 // A slice of value describing the response of the blue channel.
+}
+
+// Supported range for color temperatures, in Kelvin.
+const (
+	MinColorTemperature = 1000
+	MaxColorTemperature = 10000
+)
+
+var (
+	gammaMu        sync.Mutex
+	originalGamma  = make(map[glfw.Monitor]*glfw.GammaRamp) // Gamma ramps before they were first modified.
+	persistedGamma = make(map[string]*glfw.GammaRamp)       // Gamma ramps to reapply on reconnect, by monitor name.
+)
+
+// GetGammaRamp retrieves the current gamma ramp of the monitor.
+func (m *Monitor) GetGammaRamp() *GammaRamp {
+	var ramp *glfw.GammaRamp
+	enqueue(true, func() {
+		ramp = m.Monitor.GetGammaRamp()
+	})
+	if ramp == nil {
+		return nil
+	}
+	return &GammaRamp{Red: ramp.Red, Green: ramp.Green, Blue: ramp.Blue}
+}
+
+// SetGammaRamp sets the current gamma ramp for the monitor.
+// The original gamma ramp is remembered and can be restored with ResetGamma.
+func (m *Monitor) SetGammaRamp(ramp *GammaRamp) {
+	r := &glfw.GammaRamp{Red: ramp.Red, Green: ramp.Green, Blue: ramp.Blue}
+	enqueue(false, func() {
+		m.saveOriginalGamma()
+		m.Monitor.SetGammaRamp(r)
+		updatePersistedGamma(m.Monitor)
+	})
+}
+
+// SetGamma generates a gamma ramp from the specified exponent and then calls
+// SetGammaRamp with it.
+func (m *Monitor) SetGamma(gamma float32) {
+	enqueue(false, func() {
+		m.saveOriginalGamma()
+		m.Monitor.SetGamma(gamma)
+		updatePersistedGamma(m.Monitor)
+	})
+}
+
+// SetColorTemperature tints the monitor by applying a gamma ramp approximating the color of a blackbody
+// with the given temperature in Kelvin, similar to night light features.
+//
+// 6600 Kelvin leaves the colors unchanged, lower values shift the display towards red.
+// The temperature is clamped to [MinColorTemperature, MaxColorTemperature].
+// The call has no effect if the monitor has no usable gamma ramp.
+func (m *Monitor) SetColorTemperature(kelvin int) {
+	enqueue(false, func() {
+		current := m.Monitor.GetGammaRamp()
+		if current == nil || len(current.Red) < 2 {
+			return
+		}
+		m.saveOriginalGamma()
+		m.Monitor.SetGammaRamp(colorTemperatureRamp(kelvin, len(current.Red)))
+		updatePersistedGamma(m.Monitor)
+	})
+}
+
+// ResetGamma restores the gamma ramp the monitor had before it was first modified through this package.
+func (m *Monitor) ResetGamma() {
+	enqueue(false, func() {
+		gammaMu.Lock()
+		ramp, ok := originalGamma[*m.Monitor]
+		delete(originalGamma, *m.Monitor)
+		gammaMu.Unlock()
+
+		if ok {
+			m.Monitor.SetGammaRamp(ramp)
+			updatePersistedGamma(m.Monitor)
+		}
+	})
+}
+
+// saveOriginalGamma remembers the monitor's gamma ramp, unless it was already modified before.
+// Must be called on the render thread.
+func (m *Monitor) saveOriginalGamma() {
+	gammaMu.Lock()
+	defer gammaMu.Unlock()
+
+	if _, ok := originalGamma[*m.Monitor]; !ok {
+		originalGamma[*m.Monitor] = m.Monitor.GetGammaRamp()
+	}
+}
+
+// PersistGamma defines whether the monitor's gamma ramp should be reapplied when the monitor reconnects.
+//
+// Some systems reset gamma ramps when a monitor is unplugged, or sleeps and wakes up.
+// If enabled, the current gamma ramp and all subsequent changes made through this package are remembered
+// and reapplied automatically when a monitor with the same name is connected.
+func (m *Monitor) PersistGamma(enabled bool) {
+	enqueue(false, func() {
+		persistGamma(m.Monitor, enabled)
+	})
+}
+
+// persistGamma enables or disables gamma ramp persistence for the monitor.
+// Must be called on the render thread.
+func persistGamma(m gammaMonitor, enabled bool) {
+	name := m.GetName()
+
+	gammaMu.Lock()
+	defer gammaMu.Unlock()
+	if enabled {
+		persistedGamma[name] = m.GetGammaRamp()
+	} else {
+		delete(persistedGamma, name)
+	}
+}
+
+// gammaMonitor is the part of a glfw monitor needed to persist gamma ramps. It allows persistence to be tested without a display.
+type gammaMonitor interface {
+	GetName() string
+	GetGammaRamp() *glfw.GammaRamp
+	SetGammaRamp(ramp *glfw.GammaRamp)
+}
+
+// updatePersistedGamma remembers the monitor's current gamma ramp, if persistence is enabled.
+// Must be called on the render thread.
+func updatePersistedGamma(m gammaMonitor) {
+	name := m.GetName()
+
+	gammaMu.Lock()
+	defer gammaMu.Unlock()
+	if _, ok := persistedGamma[name]; ok {
+		persistedGamma[name] = m.GetGammaRamp()
+	}
+}
+
+// reapplyPersistedGamma applies the remembered gamma ramp to a reconnected monitor.
+// Must be called on the render thread.
+func reapplyPersistedGamma(m gammaMonitor) {
+	gammaMu.Lock()
+	ramp, ok := persistedGamma[m.GetName()]
+	gammaMu.Unlock()
+
+	if ok {
+		m.SetGammaRamp(ramp)
+	}
+}
+
+// colorTemperatureRamp computes a linear gamma ramp of the given size, scaled by the color of the given blackbody temperature.
+func colorTemperatureRamp(kelvin int, size int) *glfw.GammaRamp {
+	r, g, b := blackbodyColor(kelvin)
+	ramp := &glfw.GammaRamp{
+		Red:   make([]uint16, size),
+		Green: make([]uint16, size),
+		Blue:  make([]uint16, size),
+	}
+	for i := 0; i < size; i++ {
+		v := 65535 * float64(i) / float64(size-1)
+		ramp.Red[i] = uint16(v*r + 0.5)
+		ramp.Green[i] = uint16(v*g + 0.5)
+		ramp.Blue[i] = uint16(v*b + 0.5)
+	}
+	return ramp
+}
+
+// blackbodyColor approximates the normalized RGB color of a blackbody with the given temperature in Kelvin.
+// Based on Tanner Helland's curve fit of the CIE 1964 color matching functions.
+func blackbodyColor(kelvin int) (r, g, b float64) {
+	if kelvin < MinColorTemperature {
+		kelvin = MinColorTemperature
+	}
+	if kelvin > MaxColorTemperature {
+		kelvin = MaxColorTemperature
+	}
+	t := float64(kelvin) / 100
+
+	if t <= 66 {
+		r = 255
+		g = 99.4708025861*math.Log(t) - 161.1195681661
+	} else {
+		r = 329.698727446 * math.Pow(t-60, -0.1332047592)
+		g = 288.1221695283 * math.Pow(t-60, -0.0755148492)
+	}
+	switch {
+	case t >= 66:
+		b = 255
+	case t <= 19:
+		b = 0
+	default:
+		b = 138.5177312231*math.Log(t-10) - 305.0447927307
+	}
+	return clampColor(r), clampColor(g), clampColor(b)
+}
+
+func clampColor(c float64) float64 {
+	return math.Max(0, math.Min(255, c)) / 255
+}
@@ -0,0 +1,101 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestColorTemperatureRamp(t *testing.T) {
+	tests := []struct {
+		name                string
+		kelvin              int
+		size                int
+		wantR, wantG, wantB uint16 // Last ramp entry.
+	}{
+		{"neutral", 6600, 256, 65535, 65535, 65535},
+		{"clamped above", 20000, 2, 51838, 56044, 65535},
+		{"clamped below", 0, 2, 65535, 17456, 0},
+		{"minimum size", 6600, 2, 65535, 65535, 65535},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ramp := colorTemperatureRamp(tt.kelvin, tt.size)
+			if len(ramp.Red) != tt.size || len(ramp.Green) != tt.size || len(ramp.Blue) != tt.size {
+				t.Fatalf("got sizes %d/%d/%d, want %d", len(ramp.Red), len(ramp.Green), len(ramp.Blue), tt.size)
+			}
+			if ramp.Red[0] != 0 || ramp.Green[0] != 0 || ramp.Blue[0] != 0 {
+				t.Errorf("first entry: got (%d, %d, %d), want black", ramp.Red[0], ramp.Green[0], ramp.Blue[0])
+			}
+			last := tt.size - 1
+			r, g, b := ramp.Red[last], ramp.Green[last], ramp.Blue[last]
+			if r != tt.wantR || g != tt.wantG || b != tt.wantB {
+				t.Errorf("last entry: got (%d, %d, %d), want (%d, %d, %d)", r, g, b, tt.wantR, tt.wantG, tt.wantB)
+			}
+			for i := 1; i < tt.size; i++ {
+				if ramp.Red[i] < ramp.Red[i-1] || ramp.Green[i] < ramp.Green[i-1] || ramp.Blue[i] < ramp.Blue[i-1] {
+					t.Fatalf("ramp is not monotonic at %d", i)
+				}
+			}
+		})
+	}
+}
+
+type fakeGammaMonitor struct {
+	name string
+	ramp *glfw.GammaRamp
+}
+
+func (m *fakeGammaMonitor) GetName() string                   { return m.name }
+func (m *fakeGammaMonitor) GetGammaRamp() *glfw.GammaRamp     { return m.ramp }
+func (m *fakeGammaMonitor) SetGammaRamp(ramp *glfw.GammaRamp) { m.ramp = ramp }
+
+func TestPersistedGammaReconnect(t *testing.T) {
+	defer func(previous map[string]*glfw.GammaRamp) { persistedGamma = previous }(persistedGamma)
+	persistedGamma = make(map[string]*glfw.GammaRamp)
+	SetMonitorCallback(nil)
+
+	linear := colorTemperatureRamp(6600, 4)
+	warm := colorTemperatureRamp(3000, 4)
+
+	persisted := &fakeGammaMonitor{name: "persisted", ramp: linear}
+	other := &fakeGammaMonitor{name: "other", ramp: linear}
+	persistGamma(persisted, true)
+	persisted.SetGammaRamp(warm)
+	updatePersistedGamma(persisted)
+	other.SetGammaRamp(warm)
+	updatePersistedGamma(other)
+
+	// The system resets the ramps while the monitors are disconnected.
+	handleMonitorEvent(persisted, nil, Disconnected)
+	handleMonitorEvent(other, nil, Disconnected)
+	persisted.ramp, other.ramp = linear, linear
+
+	var events []PeripheralEvent
+	SetMonitorCallback(func(_ *Monitor, event PeripheralEvent) {
+		events = append(events, event)
+	})
+	defer SetMonitorCallback(nil)
+	handleMonitorEvent(persisted, nil, Connected)
+	handleMonitorEvent(other, nil, Connected)
+
+	if !reflect.DeepEqual(persisted.ramp, warm) {
+		t.Errorf("persisted ramp was not reapplied: got %v, want %v", persisted.ramp, warm)
+	}
+	if !reflect.DeepEqual(other.ramp, linear) {
+		t.Errorf("ramp of monitor without persistence was changed: got %v, want %v", other.ramp, linear)
+	}
+	if want := []PeripheralEvent{Connected, Connected}; !reflect.DeepEqual(events, want) {
+		t.Errorf("monitor callback got %v, want %v", events, want)
+	}
+
+	persistGamma(persisted, false)
+	persisted.ramp = linear
+	handleMonitorEvent(persisted, nil, Connected)
+	if !reflect.DeepEqual(persisted.ramp, linear) {
+		t.Error("ramp was reapplied after persistence was disabled")
+	}
+}
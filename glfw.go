@@ -6,6 +6,23 @@
 // Note: This package is currently in development. The API is incomplete and may change.
 package glfw
 
+import "errors"
+
+// Errors reported by this package. Returned errors wrap them with additional context,
+// so they should be checked using errors.Is.
+var (
+	// ErrNotInitialized is returned if the library is used before Init or after Terminate.
+	ErrNotInitialized = errors.New("not initialized")
+	// ErrNoWindow is returned if an operation requires a window or current context, but there is none.
+	ErrNoWindow = errors.New("no window")
+	// ErrContextCreationFailed is returned if a window or its context could not be created.
+	ErrContextCreationFailed = errors.New("context creation failed")
+	// ErrUnsupported is returned if the requested functionality isn't supported by the system or backend.
+	ErrUnsupported = errors.New("unsupported")
+	// ErrFormatUnavailable is returned if data, like the clipboard contents, isn't available in the requested format.
+	ErrFormatUnavailable = errors.New("format unavailable")
+)
+
 // ContextWatcher is a general mechanism for being notified when context is made current or detached.
 type ContextWatcher interface {
 	// OnMakeCurrent is called after a context is made current.
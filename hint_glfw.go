@@ -3,17 +3,47 @@
 package glfw
 
 import "C"
-import "github.com/go-gl/glfw/v3.3/glfw"
+import (
+	"runtime"
+	"sync"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
 
 type Hint int
 
-// Init related hints. (Use with glfw.InitHint)
+// Init related hints. (Use with InitHint)
 const (
 	JoystickHatButtons  = Hint(glfw.JoystickHatButtons)  // Specifies whether to also expose joystick hats as buttons, for compatibility with earlier versions of GLFW that did not have glfwGetJoystickHats.
 	CocoaChdirResources = Hint(glfw.CocoaChdirResources) // Specifies whether to set the current directory to the application to the Contents/Resources subdirectory of the application's bundle, if present.
 	CocoaMenubar        = Hint(glfw.CocoaMenubar)        // Specifies whether to create a basic menu bar, either from a nib or manually, when the first window is created, which is when AppKit is initialized.
+	AnglePlatformType   = Hint(0x00050002)               // Specifies the platform type (rendering backend) to request when using OpenGL ES and EGL via ANGLE. Requires glfw 3.4. (GLFW_ANGLE_PLATFORM_TYPE, not exposed by go-gl/glfw)
+)
+
+// Values for the AnglePlatformType init hint.
+const (
+	AnglePlatformTypeNone   = 0x00037001
+	AnglePlatformTypeOpenGL = 0x00037002
+	AnglePlatformTypeD3D11  = 0x00037005
+	AnglePlatformTypeVulkan = 0x00037007
+	AnglePlatformTypeMetal  = 0x00037008
 )
 
+// InitHint sets hints for the next initialization of glfw.
+// Init hints must be set before calling Init or InitVulkan; they have no effect on an initialized library.
+// Like Init, it must be called on the main thread.
+//
+// AnglePlatformType requires glfw 3.4. With older glfw versions, like the one bundled with go-gl/glfw v3.3, it is ignored.
+func InitHint(hint Hint, value int) {
+	if hint == AnglePlatformType && !versionAtLeast(3, 4) {
+		return
+	}
+	setInitHint(glfw.Hint(hint), value)
+}
+
+// setInitHint sets a glfw init hint. Replaced by tests.
+var setInitHint = glfw.InitHint
+
 // Window related hints/attributes.
 const (
 	Focused                = Hint(glfw.Focused)                // Specifies whether the window will be given input focus when created. This hint is ignored for full screen and initially hidden windows.
@@ -29,8 +59,15 @@ const (
 	TransparentFramebuffer = Hint(glfw.TransparentFramebuffer) // Specifies whether the framebuffer should be transparent.
 	FocusOnShow            = Hint(glfw.FocusOnShow)            // Specifies whether the window will be given input focus when glfwShowWindow is called.
 	ScaleToMonitor         = Hint(glfw.ScaleToMonitor)         // Specified whether the window content area should be resized based on the monitor content scale of any monitor it is placed on. This includes the initial placement when the window is created.
+	MousePassthrough       = Hint(0x0002000D)                  // Specifies whether the window is transparent to mouse input, letting any mouse events pass through to whatever window is behind it. Requires glfw 3.4. (GLFW_MOUSE_PASSTHROUGH, not exposed by go-gl/glfw)
+	ScaleFramebuffer       = Hint(0x0002200D)                  // Specifies whether the framebuffer should be scaled on HiDPI displays, where the content scale is not 1. Requires glfw 3.4. (GLFW_SCALE_FRAMEBUFFER, not exposed by go-gl/glfw)
+	PositionX              = Hint(0x0002000E)                  // Specifies the initial x-coordinate of the window's content area, or AnyPosition. Requires glfw 3.4. (GLFW_POSITION_X, not exposed by go-gl/glfw)
+	PositionY              = Hint(0x0002000F)                  // Specifies the initial y-coordinate of the window's content area, or AnyPosition. Requires glfw 3.4. (GLFW_POSITION_Y, not exposed by go-gl/glfw)
 )
 
+// AnyPosition lets the window manager place the window. It is the default value of the PositionX and PositionY hints.
+const AnyPosition = -0x80000000 // GLFW_ANY_POSITION
+
 // Context related hints.
 const (
 	ClientAPI               = Hint(glfw.ClientAPI)               // Specifies which client API to create the context for. Hard constraint.
@@ -42,8 +79,40 @@ const (
 	OpenGLDebugContext      = Hint(glfw.OpenGLDebugContext)      // Specifies whether to create a debug OpenGL context, which may have additional error and performance issue reporting functionality. If OpenGL ES is requested, this hint is ignored.
 	OpenGLProfile           = Hint(glfw.OpenGLProfile)           // Specifies which OpenGL profile to create the context for. Hard constraint.
 	ContextCreationAPI      = Hint(glfw.ContextCreationAPI)      // Specifies which context creation API to use to create the context.
+	ContextNoError          = Hint(0x0002200A)                   // Specifies whether errors should be generated by the context. If enabled, situations that would have generated errors instead cause undefined behavior. (GLFW_CONTEXT_NO_ERROR, not exposed by go-gl/glfw)
+)
+
+// Values for the ContextCreationAPI hint.
+const (
+	NativeContextAPI = glfw.NativeContextAPI
+	EGLContextAPI    = glfw.EGLContextAPI
+	OSMesaContextAPI = glfw.OSMesaContextAPI
+)
+
+// Values for the ClientAPI hint.
+const (
+	OpenGLAPI   = glfw.OpenGLAPI
+	OpenGLESAPI = glfw.OpenGLESAPI
+	NoAPI       = glfw.NoAPI
+)
+
+// Values for the ContextRobustness hint.
+const (
+	NoRobustness        = glfw.NoRobustness        // The context doesn't use a robustness strategy.
+	NoResetNotification = glfw.NoResetNotification // The application isn't notified of graphics resets; the context keeps running after a reset, with undefined contents.
+	LoseContextOnReset  = glfw.LoseContextOnReset  // The context is lost on graphics resets; the application must detect this via glGetGraphicsResetStatus and recreate the context.
 )
 
+// Values for the ContextReleaseBehavior hint.
+const (
+	AnyReleaseBehavior   = glfw.AnyReleaseBehavior   // Uses the default behavior of the context creation API.
+	ReleaseBehaviorFlush = glfw.ReleaseBehaviorFlush // Flushes the pipeline whenever the context is released from being current.
+	ReleaseBehaviorNone  = glfw.ReleaseBehaviorNone  // Doesn't flush the pipeline on release.
+)
+
+// DontCare disables a limit or lets glfw choose a value.
+const DontCare = glfw.DontCare
+
 // Framebuffer related hints.
 const (
 	ContextRevision        = Hint(glfw.ContextRevision)
@@ -85,10 +154,154 @@ const (
 // noopHint is ignored.
 const noopHint Hint = -1
 
+// WindowHint sets hints for the next call to CreateWindow. The hints,
+// once set, retain their values until changed by a call to WindowHint or
+// DefaultWindowHints, or until the library is terminated.
+//
+// Hints are applied on the render thread, in order with window creation.
+// Since they are global state, goroutines that create windows concurrently should pass their hints
+// to CreateWindowWithOptions instead, which applies them atomically with the window creation.
+//
+// ScaleFramebuffer supersedes CocoaRetinaFramebuffer, which only affects macOS.
+// Disable it to get a framebuffer with one pixel per screen coordinate on HiDPI displays,
+// and disable ScaleToMonitor as well to keep the window size from being scaled.
+// With glfw versions older than 3.4, ScaleFramebuffer is applied as CocoaRetinaFramebuffer instead.
+//
+// PositionX and PositionY are ignored with glfw versions older than 3.4; use CreateWindowAt to position windows portably.
 func WindowHint(target Hint, hint int) {
-	if target == noopHint {
+	target, ok := resolveHint(target)
+	if !ok {
 		return
 	}
 
-	glfw.WindowHint(glfw.Hint(target), hint)
+	hintMu.Lock()
+	hintValues[target] = hint
+	hintMu.Unlock()
+
+	enqueue(false, func() {
+		windowHint(glfw.Hint(target), hint)
+	})
+}
+
+// resolveHint returns the hint to apply for target, considering the glfw version.
+// It returns false if the hint must be ignored.
+func resolveHint(target Hint) (Hint, bool) {
+	if target == noopHint {
+		return target, false
+	}
+	if (target == PositionX || target == PositionY) && !versionAtLeast(3, 4) {
+		return target, false
+	}
+	if target == ScaleFramebuffer && !versionAtLeast(3, 4) {
+		return CocoaRetinaFramebuffer, true
+	}
+	return target, true
+}
+
+var (
+	hintMu      sync.Mutex
+	hintValues  = make(map[Hint]int)    // Set via WindowHint since the last call to DefaultWindowHints.
+	hintStrings = make(map[Hint]string) // Set via WindowHintString since the last call to DefaultWindowHints.
+)
+
+// hintValue returns the value last set via WindowHint, or def if it has not been set.
+func hintValue(target Hint, def int) int {
+	hintMu.Lock()
+	defer hintMu.Unlock()
+	if value, ok := hintValues[target]; ok {
+		return value
+	}
+	return def
+}
+
+// forgetHintValues is called when hints are reset to their default values.
+func forgetHintValues() {
+	hintMu.Lock()
+	defer hintMu.Unlock()
+	hintValues = make(map[Hint]int)
+	hintStrings = make(map[Hint]string)
+}
+
+// The hint functions of glfw. They are replaceable to test window creation without a display.
+var (
+	windowHint         = glfw.WindowHint
+	windowHintString   = glfw.WindowHintString
+	defaultWindowHints = glfw.DefaultWindowHints
+)
+
+// restoreHints resets glfw's hints to the values set via WindowHint and WindowHintString,
+// discarding hints that were applied temporarily. Must be called on the render thread.
+func restoreHints() {
+	defaultWindowHints()
+	applyHints(func(target Hint, value int) {
+		windowHint(glfw.Hint(target), value)
+	}, func(target Hint, value string) {
+		windowHintString(glfw.Hint(target), value)
+	})
+}
+
+// applyHints passes the package's default hints, followed by the hints set via WindowHint and WindowHintString,
+// to setHint and setHintString.
+func applyHints(setHint func(target Hint, value int), setHintString func(target Hint, value string)) {
+	if noAPI {
+		setHint(ClientAPI, NoAPI)
+	}
+
+	hintMu.Lock()
+	defer hintMu.Unlock()
+	for target, value := range hintValues {
+		setHint(target, value)
+	}
+	for target, value := range hintStrings {
+		setHintString(target, value)
+	}
+}
+
+// HintForwardCompatible sets the OpenGLForwardCompatible hint, which is required for OpenGL 3.2+ core profile contexts on macOS.
+func HintForwardCompatible(enabled bool) {
+	WindowHint(OpenGLForwardCompatible, boolHint(enabled))
+}
+
+// HintDebugContext sets the OpenGLDebugContext hint.
+func HintDebugContext(enabled bool) {
+	WindowHint(OpenGLDebugContext, boolHint(enabled))
+}
+
+// boolHint converts a boolean to the value of a boolean hint or attribute.
+func boolHint(b bool) int {
+	if b {
+		return glfw.True
+	}
+	return glfw.False
+}
+
+// WindowHintString sets hints for the next call to CreateWindow. The hints,
+// once set, retain their values until changed by a call to WindowHintString or
+// DefaultWindowHints, or until the library is terminated.
+//
+// Only string type hints can be set with this function, like CocoaFrameNAME, X11ClassName and X11InstanceName.
+// Like WindowHint, the hints are applied on the render thread.
+func WindowHintString(target Hint, value string) {
+	hintMu.Lock()
+	hintStrings[target] = value
+	hintMu.Unlock()
+
+	enqueue(false, func() {
+		windowHintString(glfw.Hint(target), value)
+	})
+}
+
+// SetFrameAutosaveName sets the name under which macOS autosaves the frame of the next created window,
+// so that its position and size are restored across launches.
+// An empty name disables autosaving.
+//
+// This is a no-op on other platforms.
+func SetFrameAutosaveName(name string) {
+	if !frameAutosaveSupported {
+		return
+	}
+	WindowHintString(CocoaFrameNAME, name)
 }
+
+// frameAutosaveSupported reports whether the platform autosaves window frames. Replaced by tests.
+var frameAutosaveSupported = runtime.GOOS == "darwin"
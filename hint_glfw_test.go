@@ -0,0 +1,249 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// recordHints replaces enqueue by a stub that counts commands without executing them, so no glfw functions are called.
+// It resets the recorded hint values and returns a function restoring the previous state.
+func recordHints(enqueued *int) (restore func()) {
+	previous := enqueue
+	enqueue = func(_ bool, fn func()) { *enqueued++ }
+	forgetHintValues()
+	return func() {
+		enqueue = previous
+		forgetHintValues()
+	}
+}
+
+func TestSetFrameAutosaveName(t *testing.T) {
+	defer func(previous bool) { frameAutosaveSupported = previous }(frameAutosaveSupported)
+
+	for _, supported := range []bool{true, false} {
+		var enqueued int
+		restore := recordHints(&enqueued)
+		frameAutosaveSupported = supported
+
+		SetFrameAutosaveName("main")
+		hintMu.Lock()
+		name, ok := hintStrings[CocoaFrameNAME]
+		hintMu.Unlock()
+		if supported {
+			if !ok || name != "main" || enqueued != 1 {
+				t.Errorf("darwin: got hint %q (set %v, %d commands), want %q applied", name, ok, enqueued, "main")
+			}
+		} else if ok || enqueued != 0 {
+			t.Errorf("other platforms: got hint %q (set %v, %d commands), want no hint", name, ok, enqueued)
+		}
+		restore()
+	}
+}
+
+func TestReleaseBehaviorValues(t *testing.T) {
+	// Values as defined by glfw3.h.
+	tests := []struct {
+		name      string
+		got, want int
+	}{
+		{"GLFW_CONTEXT_RELEASE_BEHAVIOR", int(ContextReleaseBehavior), 0x00022009},
+		{"GLFW_ANY_RELEASE_BEHAVIOR", AnyReleaseBehavior, 0},
+		{"GLFW_RELEASE_BEHAVIOR_FLUSH", ReleaseBehaviorFlush, 0x00035001},
+		{"GLFW_RELEASE_BEHAVIOR_NONE", ReleaseBehaviorNone, 0x00035002},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %#x, want %#x", tt.name, tt.got, tt.want)
+		}
+	}
+}
+
+func TestRobustnessStrategy(t *testing.T) {
+	// Values as defined by glfw3.h.
+	tests := []struct {
+		name      string
+		got, want int
+	}{
+		{"GLFW_CONTEXT_ROBUSTNESS", int(ContextRobustness), 0x00022005},
+		{"GLFW_NO_ROBUSTNESS", NoRobustness, 0},
+		{"GLFW_NO_RESET_NOTIFICATION", NoResetNotification, 0x00031001},
+		{"GLFW_LOSE_CONTEXT_ON_RESET", LoseContextOnReset, 0x00031002},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %#x, want %#x", tt.name, tt.got, tt.want)
+		}
+	}
+
+	for _, strategy := range []int{NoRobustness, NoResetNotification, LoseContextOnReset} {
+		win := &fakeNativeWindow{attribs: map[glfw.Hint]int{glfw.ContextRobustness: strategy}}
+		if got := robustnessStrategy(win); got != strategy {
+			t.Errorf("got strategy %#x, want %#x", got, strategy)
+		}
+	}
+}
+
+func TestScaleFramebuffer(t *testing.T) {
+	if ScaleFramebuffer != 0x0002200D { // GLFW_SCALE_FRAMEBUFFER
+		t.Errorf("got %#x, want GLFW_SCALE_FRAMEBUFFER", int(ScaleFramebuffer))
+	}
+
+	previous := linkedVersion
+	defer func() { linkedVersion = previous }()
+	tests := []struct {
+		minor int
+		want  Hint
+	}{
+		{3, CocoaRetinaFramebuffer},
+		{4, ScaleFramebuffer},
+	}
+	for _, tt := range tests {
+		linkedVersion = func() (int, int, int) { return 3, tt.minor, 0 }
+		if got, ok := resolveHint(ScaleFramebuffer); got != tt.want || !ok {
+			t.Errorf("glfw 3.%d: applied as %#x (%v), want %#x", tt.minor, int(got), ok, int(tt.want))
+		}
+	}
+}
+
+func TestInitHintAnglePlatformType(t *testing.T) {
+	// Values as defined by glfw3.h.
+	tests := []struct {
+		name      string
+		got, want int
+	}{
+		{"GLFW_ANGLE_PLATFORM_TYPE", int(AnglePlatformType), 0x00050002},
+		{"GLFW_ANGLE_PLATFORM_TYPE_NONE", AnglePlatformTypeNone, 0x00037001},
+		{"GLFW_ANGLE_PLATFORM_TYPE_OPENGL", AnglePlatformTypeOpenGL, 0x00037002},
+		{"GLFW_ANGLE_PLATFORM_TYPE_D3D11", AnglePlatformTypeD3D11, 0x00037005},
+		{"GLFW_ANGLE_PLATFORM_TYPE_VULKAN", AnglePlatformTypeVulkan, 0x00037007},
+		{"GLFW_ANGLE_PLATFORM_TYPE_METAL", AnglePlatformTypeMetal, 0x00037008},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("%s: got %#x, want %#x", tt.name, tt.got, tt.want)
+		}
+	}
+
+	previousVersion, previousSet := linkedVersion, setInitHint
+	defer func() { linkedVersion, setInitHint = previousVersion, previousSet }()
+	applied := make(map[glfw.Hint]int)
+	setInitHint = func(hint glfw.Hint, value int) { applied[hint] = value }
+
+	linkedVersion = func() (int, int, int) { return 3, 3, 8 }
+	InitHint(AnglePlatformType, AnglePlatformTypeD3D11)
+	if len(applied) != 0 {
+		t.Errorf("glfw 3.3: applied %v, want the hint to be ignored", applied)
+	}
+
+	linkedVersion = func() (int, int, int) { return 3, 4, 0 }
+	InitHint(AnglePlatformType, AnglePlatformTypeD3D11)
+	if got := applied[glfw.Hint(AnglePlatformType)]; got != AnglePlatformTypeD3D11 {
+		t.Errorf("glfw 3.4: applied %#x, want %#x", got, AnglePlatformTypeD3D11)
+	}
+}
+
+func TestContextHints(t *testing.T) {
+	tests := []struct {
+		name    string
+		set     func(enabled bool)
+		hint    Hint
+		enabled bool
+		want    int
+	}{
+		{"forward compatible", HintForwardCompatible, OpenGLForwardCompatible, true, glfw.True},
+		{"not forward compatible", HintForwardCompatible, OpenGLForwardCompatible, false, glfw.False},
+		{"debug context", HintDebugContext, OpenGLDebugContext, true, glfw.True},
+		{"no debug context", HintDebugContext, OpenGLDebugContext, false, glfw.False},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enqueued int
+			defer recordHints(&enqueued)()
+
+			tt.set(tt.enabled)
+			if got := hintValue(tt.hint, -1); got != tt.want || enqueued != 1 {
+				t.Errorf("got hint %d (%d commands), want %d applied", got, enqueued, tt.want)
+			}
+		})
+	}
+}
+
+func TestAttribEnabled(t *testing.T) {
+	win := &fakeNativeWindow{attribs: map[glfw.Hint]int{
+		glfw.OpenGLForwardCompatible: glfw.True,
+		glfw.OpenGLDebugContext:      glfw.False,
+	}}
+	if !attribEnabled(win, OpenGLForwardCompatible) {
+		t.Error("forward-compatible context not reported")
+	}
+	if attribEnabled(win, OpenGLDebugContext) {
+		t.Error("debug context reported for a regular context")
+	}
+	if attribEnabled(win, ContextNoError) {
+		t.Error("unset attribute reported as enabled")
+	}
+}
+
+func TestContextNoError(t *testing.T) {
+	if ContextNoError != 0x0002200A { // GLFW_CONTEXT_NO_ERROR
+		t.Errorf("got %#x, want GLFW_CONTEXT_NO_ERROR", int(ContextNoError))
+	}
+
+	var enqueued int
+	defer recordHints(&enqueued)()
+	WindowHint(ContextNoError, glfw.True)
+	if got := hintValue(ContextNoError, -1); got != glfw.True || enqueued != 1 {
+		t.Errorf("got hint %d (%d commands), want %d applied", got, enqueued, glfw.True)
+	}
+
+	win := &fakeNativeWindow{attribs: map[glfw.Hint]int{glfw.Hint(ContextNoError): glfw.True}}
+	if !attribEnabled(win, ContextNoError) {
+		t.Error("no-error context not reported")
+	}
+}
+
+func TestApplyHints(t *testing.T) {
+	defer func(previous bool) { noAPI = previous }(noAPI)
+
+	tests := []struct {
+		name  string
+		noAPI bool
+		hints map[Hint]int // Set via WindowHint.
+		want  int          // Resulting ClientAPI hint, or -1 if not applied.
+	}{
+		{"GL", false, nil, -1},
+		{"Vulkan", true, nil, NoAPI},
+		{"Vulkan after DefaultWindowHints", true, map[Hint]int{Resizable: glfw.False}, NoAPI},
+		{"Vulkan with explicit client API", true, map[Hint]int{ClientAPI: OpenGLESAPI}, OpenGLESAPI},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var enqueued int
+			defer recordHints(&enqueued)()
+			noAPI = tt.noAPI
+			for target, value := range tt.hints {
+				WindowHint(target, value)
+			}
+
+			applied := make(map[Hint]int)
+			applyHints(func(target Hint, value int) {
+				applied[target] = value
+			}, func(Hint, string) {})
+			got, ok := applied[ClientAPI]
+			if !ok {
+				got = -1
+			}
+			if got != tt.want {
+				t.Errorf("got ClientAPI %#x, want %#x", got, tt.want)
+			}
+			for target, value := range tt.hints {
+				if applied[target] != value {
+					t.Errorf("hint %#x: got %d, want %d", int(target), applied[target], value)
+				}
+			}
+		})
+	}
+}
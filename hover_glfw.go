@@ -0,0 +1,88 @@
+// +build !js
+
+package glfw
+
+import "time"
+
+// hoverIntent tracks whether the cursor dwells inside the window.
+type hoverIntent struct {
+	callback func(w *Window, hovering bool) // Nil if disabled.
+	dwell    time.Duration
+	timer    hoverTimer
+	seq      int // Incremented whenever the cursor leaves, invalidating pending timers.
+	hovering bool
+}
+
+// hoverTimer is a pending dwell timer.
+type hoverTimer interface {
+	Stop() bool
+}
+
+// startHoverTimer calls f after the dwell duration d. It is replaceable to control time in tests.
+var startHoverTimer = func(d time.Duration, f func()) hoverTimer {
+	return time.AfterFunc(d, f)
+}
+
+// SetHoverCallback sets a callback reporting whether the user hovers over the window.
+// hovering=true is reported once the cursor stayed inside the content area for dwell,
+// hovering=false is reported immediately when the cursor leaves it again.
+//
+// This is intended to defer expensive hover effects until the user genuinely dwells over the window.
+// The cursor enter callback keeps working independently. The callback is called on the render thread.
+// Passing nil removes the callback.
+func (w *Window) SetHoverCallback(cbfun func(w *Window, hovering bool), dwell time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.stopHoverTimer()
+	w.hover.callback = cbfun
+	w.hover.dwell = dwell
+	w.hover.hovering = false
+}
+
+// onCursorEnter is called on the render thread when the cursor enters or leaves the content area.
+func (w *Window) onCursorEnter(entered bool) {
+	w.mu.Lock()
+	cbfun := w.cursorEnterCallback
+	hoverCallback := w.hover.callback
+	wasHovering := w.hover.hovering
+	w.stopHoverTimer()
+	w.hover.hovering = false
+	if entered && hoverCallback != nil {
+		seq := w.hover.seq
+		w.hover.timer = startHoverTimer(w.hover.dwell, func() {
+			enqueue(false, func() { w.onHoverDwell(seq) })
+		})
+	}
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, entered)
+	}
+	if wasHovering && hoverCallback != nil {
+		hoverCallback(w, false)
+	}
+}
+
+// onHoverDwell is called on the render thread once the cursor dwelled inside the content area.
+func (w *Window) onHoverDwell(seq int) {
+	w.mu.Lock()
+	if seq != w.hover.seq || w.hover.callback == nil {
+		w.mu.Unlock()
+		return // The cursor left in the meantime.
+	}
+	w.hover.timer = nil
+	w.hover.hovering = true
+	cbfun := w.hover.callback
+	w.mu.Unlock()
+
+	cbfun(w, true)
+}
+
+// stopHoverTimer cancels a pending dwell timer. w.mu must be held.
+func (w *Window) stopHoverTimer() {
+	if w.hover.timer != nil {
+		w.hover.timer.Stop()
+		w.hover.timer = nil
+	}
+	w.hover.seq++
+}
@@ -0,0 +1,80 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type fakeHoverTimer struct {
+	fire    func()
+	stopped bool
+}
+
+func (t *fakeHoverTimer) Stop() bool {
+	wasActive := !t.stopped
+	t.stopped = true
+	return wasActive
+}
+
+func TestHoverCallback(t *testing.T) {
+	var timers []*fakeHoverTimer
+	defer func(start func(time.Duration, func()) hoverTimer) { startHoverTimer = start }(startHoverTimer)
+	startHoverTimer = func(d time.Duration, f func()) hoverTimer {
+		if d != time.Second {
+			t.Errorf("dwell: got %v, want %v", d, time.Second)
+		}
+		timer := &fakeHoverTimer{fire: f}
+		timers = append(timers, timer)
+		return timer
+	}
+	defer func(e func(bool, func())) { enqueue = e }(enqueue)
+	enqueue = func(_ bool, fn func()) { fn() }
+
+	tests := []struct {
+		name  string
+		steps []string // "enter", "leave" or "fire <n>", firing the n-th started timer even if stopped.
+		want  []bool
+	}{
+		{"dwell", []string{"enter", "fire 0"}, []bool{true}},
+		{"left before dwell", []string{"enter", "leave", "fire 0"}, nil},
+		{"dwell and leave", []string{"enter", "fire 0", "leave"}, []bool{true, false}},
+		{"reentered", []string{"enter", "leave", "enter", "fire 0", "fire 1"}, []bool{true}},
+		{"leave without dwell", []string{"leave"}, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			timers = nil
+			var got []bool
+			w := new(Window)
+			w.SetHoverCallback(func(_ *Window, hovering bool) {
+				got = append(got, hovering)
+			}, time.Second)
+
+			for _, step := range tt.steps {
+				var n int
+				switch step {
+				case "enter":
+					w.onCursorEnter(true)
+				case "leave":
+					w.onCursorEnter(false)
+					if len(timers) > 0 && !timers[len(timers)-1].stopped {
+						t.Errorf("%s: pending timer not stopped", step)
+					}
+				default:
+					if _, err := fmt.Sscanf(step, "fire %d", &n); err != nil {
+						t.Fatalf("invalid step %q", step)
+					}
+					timers[n].stopped = true // Expired.
+					timers[n].fire()
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
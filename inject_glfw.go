@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+// The Inject methods feed synthetic input events into the window, for UI automation and deterministic tests.
+// Injected events are processed on the render thread exactly like real ones: they pass the event filter,
+// update the state queried by KeyDown and MouseButtonDown, and reach callbacks and input channels.
+//
+// They don't generate OS-level input. Other applications, and glfw functions querying the OS
+// like GetCursorPos, are not affected.
+
+// InjectKey emits a synthetic key event.
+func (w *Window) InjectKey(key Key, scancode int, action Action, mods ModifierKey) {
+	w.inject(KeyEvent{key, scancode, action, mods})
+}
+
+// InjectChar emits a synthetic character event.
+func (w *Window) InjectChar(char rune) {
+	w.inject(CharEvent{char})
+}
+
+// InjectMouseButton emits a synthetic mouse button event.
+func (w *Window) InjectMouseButton(button MouseButton, action Action, mods ModifierKey) {
+	w.inject(MouseButtonEvent{button, action, mods})
+}
+
+// InjectCursorPos emits a synthetic cursor position event.
+// The position is in screen coordinates, relative to the top-left corner of the content area.
+func (w *Window) InjectCursorPos(x, y float64) {
+	w.inject(CursorPosEvent{x, y})
+}
+
+// InjectScroll emits a synthetic scroll event.
+func (w *Window) InjectScroll(xoff, yoff float64) {
+	w.inject(ScrollEvent{xoff, yoff})
+}
+
+func (w *Window) inject(ev Event) {
+	enqueue(false, func() {
+		w.dispatch(ev)
+	})
+}
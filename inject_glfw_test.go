@@ -0,0 +1,42 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestInject(t *testing.T) {
+	defer stubEnqueue()()
+
+	w := new(Window)
+	log := logCallbacks(w)
+
+	w.InjectKey(KeyEscape, 9, Press, ModAlt)
+	w.InjectMouseButton(MouseButtonLeft, Press, 0)
+	if !w.KeyDown(KeyEscape) || !w.MouseButtonDown(MouseButtonLeft) {
+		t.Error("injected presses weren't tracked")
+	}
+	w.InjectChar('x')
+	w.InjectCursorPos(4, 5)
+	w.InjectScroll(1, 2)
+	w.InjectKey(KeyEscape, 9, Release, ModAlt)
+	w.InjectMouseButton(MouseButtonLeft, Release, 0)
+	if w.KeyDown(KeyEscape) || w.MouseButtonDown(MouseButtonLeft) {
+		t.Error("injected releases weren't tracked")
+	}
+
+	want := []string{
+		"key 256 9 1 4",
+		"button 0 1 0",
+		`char 'x'`,
+		"cursor 4 5",
+		"scroll 1 2",
+		"key 256 9 0 4",
+		"button 0 0 0",
+	}
+	if !reflect.DeepEqual(*log, want) {
+		t.Errorf("got callbacks %q, want %q", *log, want)
+	}
+}
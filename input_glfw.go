@@ -0,0 +1,189 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"sync/atomic"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// inputState holds the pressed keys and mouse buttons of a window.
+// It is updated by the input callbacks and can be read without locking.
+type inputState struct {
+	keys         [glfw.KeyLast + 1]int32         // 1 if the key is down.
+	mouseButtons [glfw.MouseButtonLast + 1]int32 // 1 if the button is down.
+}
+
+// update applies an event to the input state.
+func (s *inputState) update(ev Event) {
+	switch ev := ev.(type) {
+	case KeyEvent:
+		if ev.Key < 0 || int(ev.Key) >= len(s.keys) {
+			return
+		}
+		var down int32
+		if ev.Action != Release {
+			down = 1
+		}
+		atomic.StoreInt32(&s.keys[ev.Key], down)
+	case MouseButtonEvent:
+		if ev.Button < 0 || int(ev.Button) >= len(s.mouseButtons) {
+			return
+		}
+		var down int32
+		if ev.Action != Release {
+			down = 1
+		}
+		atomic.StoreInt32(&s.mouseButtons[ev.Button], down)
+	}
+}
+
+// releaseMouseButtons marks all mouse buttons as released.
+// This prevents stuck buttons if the release happens while another window has focus.
+func (s *inputState) releaseMouseButtons() {
+	for i := range s.mouseButtons {
+		atomic.StoreInt32(&s.mouseButtons[i], 0)
+	}
+}
+
+// KeyDown reports whether the key is currently held down. Repeated keys are considered held down.
+//
+// The key state is tracked by the window's key callback, so unlike GetKey,
+// KeyDown doesn't require a round-trip to the render thread and is cheap to call for many keys per frame.
+func (w *Window) KeyDown(key Key) bool {
+	if key < 0 || int(key) >= len(w.input.keys) {
+		return false
+	}
+	return atomic.LoadInt32(&w.input.keys[key]) == 1
+}
+
+// MouseButtonDown reports whether the mouse button is currently held down.
+//
+// The button state is tracked by the window's mouse button callback, so unlike GetMouseButton,
+// MouseButtonDown doesn't require a round-trip to the render thread.
+// All buttons are considered released when the window loses focus.
+func (w *Window) MouseButtonDown(button MouseButton) bool {
+	if button < 0 || int(button) >= len(w.input.mouseButtons) {
+		return false
+	}
+	return atomic.LoadInt32(&w.input.mouseButtons[button]) == 1
+}
+
+// mouseDelta accumulates cursor movement.
+type mouseDelta struct {
+	valid  bool // Whether lastX and lastY hold a reference position.
+	lastX  float64
+	lastY  float64
+	dx, dy float64
+}
+
+func (d *mouseDelta) move(x, y float64) {
+	if d.valid {
+		d.dx += x - d.lastX
+		d.dy += y - d.lastY
+	}
+	d.lastX, d.lastY = x, y
+	d.valid = true
+}
+
+// MouseDelta returns the cursor movement accumulated since the previous call, in screen coordinates.
+//
+// This is intended for mouse-look controls in combination with CursorDisabled.
+// The movement is tracked by the window's cursor position callback.
+// Switching the cursor mode discards the reference position, so the jump of the
+// reported cursor position when entering or leaving CursorDisabled doesn't result in a spike.
+func (w *Window) MouseDelta() (dx, dy float64) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	dx, dy = w.mouseDelta.dx, w.mouseDelta.dy
+	w.mouseDelta.dx, w.mouseDelta.dy = 0, 0
+	return dx, dy
+}
+
+// resetMouseDelta discards the reference position of the mouse delta, without losing the accumulated movement.
+func (w *Window) resetMouseDelta() {
+	w.mu.Lock()
+	w.mouseDelta.valid = false
+	w.mu.Unlock()
+}
+
+// RawMouseMotionSupported reports whether raw mouse motion is supported on the current system.
+// Raw mouse motion is closer to the actual motion of the mouse across a surface, unaffected by scaling and acceleration.
+func RawMouseMotionSupported() bool {
+	var supported bool
+	enqueue(true, func() {
+		supported = glfw.RawMouseMotionSupported()
+	})
+	return supported
+}
+
+// EnableRawMouseLook disables the cursor and enables raw mouse motion, the typical setup for mouse-look controls.
+//
+// If raw mouse motion is not supported, an error is returned and the input modes are left unchanged.
+func (w *Window) EnableRawMouseLook() error {
+	w.resetMouseDelta()
+
+	var supported bool
+	enqueue(true, func() {
+		supported = w.enableRawMouseLook(w.Window)
+	})
+	if !supported {
+		return fmt.Errorf("raw mouse motion: %w", ErrUnsupported)
+	}
+	return nil
+}
+
+// DisableRawMouseLook disables raw mouse motion and restores the normal cursor.
+func (w *Window) DisableRawMouseLook() {
+	w.resetMouseDelta()
+
+	enqueue(false, func() {
+		w.disableRawMouseLook(w.Window)
+	})
+}
+
+// rawMouseMotionSupported reports whether raw mouse motion is supported by the system. Replaced by tests.
+var rawMouseMotionSupported = glfw.RawMouseMotionSupported
+
+// enableRawMouseLook sets the input modes for mouse-look controls and reports whether raw mouse motion is supported.
+// Must be called on the render thread.
+func (w *Window) enableRawMouseLook(win nativeWindow) bool {
+	if !rawMouseMotionSupported() {
+		return false
+	}
+	win.SetInputMode(glfw.CursorMode, glfw.CursorDisabled)
+	w.trackCursorMode(glfw.CursorDisabled)
+	win.SetInputMode(glfw.RawMouseMotion, glfw.True)
+	return true
+}
+
+// disableRawMouseLook restores the input modes changed by enableRawMouseLook. Must be called on the render thread.
+func (w *Window) disableRawMouseLook(win nativeWindow) {
+	if rawMouseMotionSupported() {
+		win.SetInputMode(glfw.RawMouseMotion, glfw.False)
+	}
+	win.SetInputMode(glfw.CursorMode, glfw.CursorNormal)
+	w.trackCursorMode(glfw.CursorNormal)
+}
+
+// trackCursorMode records the cursor mode after it was changed, so that events can be processed
+// without querying glfw.
+func (w *Window) trackCursorMode(value int) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.cursorDisabled = value == glfw.CursorDisabled
+}
+
+// SetClampCursorToContent defines whether cursor positions are clamped to the content area before they are delivered
+// to cursor position callbacks, observers and input channels. Clamping is disabled by default.
+//
+// During fast movement at the window's edge, glfw occasionally reports positions slightly outside the content area.
+// Clamping keeps them within [0, width] and [0, height], in screen coordinates.
+// Positions are not clamped while the cursor is disabled, since they are virtual and unbounded then.
+func (w *Window) SetClampCursorToContent(enabled bool) {
+	w.mu.Lock()
+	w.clampCursor = enabled
+	w.mu.Unlock()
+}
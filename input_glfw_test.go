@@ -0,0 +1,178 @@
+// +build !js
+
+package glfw
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestKeyDown(t *testing.T) {
+	defer stubEnqueue()()
+
+	w := new(Window)
+	var calls int
+	w.SetKeyCallback(func(_ *Window, key Key, _ int, action Action, _ ModifierKey) {
+		calls++
+		if key == KeyUnknown {
+			return
+		}
+		if want := action != Release; w.KeyDown(key) != want {
+			t.Errorf("callback for action %d: KeyDown is %v, want %v", action, !want, want)
+		}
+	})
+
+	steps := []struct {
+		ev   KeyEvent
+		want bool
+	}{
+		{KeyEvent{Key: KeySpace, Action: Press}, true},
+		{KeyEvent{Key: KeySpace, Action: Repeat}, true},
+		{KeyEvent{Key: KeyLeftShift, Action: Press}, true},
+		{KeyEvent{Key: KeySpace, Action: Release}, false},
+	}
+	for _, step := range steps {
+		w.dispatch(step.ev)
+		if got := w.KeyDown(step.ev.Key); got != step.want {
+			t.Errorf("after key %d action %d: got %v, want %v", step.ev.Key, step.ev.Action, got, step.want)
+		}
+	}
+	if !w.KeyDown(KeyLeftShift) {
+		t.Error("releasing a key released another one")
+	}
+
+	w.dispatch(KeyEvent{Key: KeyUnknown, Action: Press})
+	if calls != len(steps)+1 {
+		t.Errorf("key callback called %d times, want %d", calls, len(steps)+1)
+	}
+	if w.KeyDown(KeyUnknown) || w.KeyDown(Key(glfw.KeyLast+1)) {
+		t.Error("keys outside the table reported as down")
+	}
+}
+
+func TestMouseButtonDown(t *testing.T) {
+	defer stubEnqueue()()
+
+	w := new(Window)
+	var calls int
+	w.SetMouseButtonCallback(func(*Window, MouseButton, Action, ModifierKey) {
+		calls++
+	})
+
+	w.dispatch(MouseButtonEvent{Button: MouseButtonLeft, Action: Press})
+	w.dispatch(MouseButtonEvent{Button: MouseButtonRight, Action: Press})
+	w.dispatch(MouseButtonEvent{Button: MouseButtonRight, Action: Release})
+	if !w.MouseButtonDown(MouseButtonLeft) || w.MouseButtonDown(MouseButtonRight) || w.MouseButtonDown(MouseButtonMiddle) {
+		t.Error("button table doesn't reflect the events")
+	}
+	if calls != 3 {
+		t.Errorf("mouse button callback called %d times, want 3", calls)
+	}
+	if w.MouseButtonDown(-1) || w.MouseButtonDown(MouseButton(glfw.MouseButtonLast+1)) {
+		t.Error("buttons outside the table reported as down")
+	}
+
+	w.onFocus(false)
+	if w.MouseButtonDown(MouseButtonLeft) {
+		t.Error("button still down after losing focus")
+	}
+}
+
+func TestMouseDelta(t *testing.T) {
+	type pos struct{ x, y float64 }
+	tests := []struct {
+		name           string
+		moves          []pos // {-1, -1} resets the reference position instead.
+		wantDX, wantDY float64
+	}{
+		{"no movement", nil, 0, 0},
+		{"first position is the reference", []pos{{10, 20}}, 0, 0},
+		{"accumulates", []pos{{10, 20}, {15, 18}, {20, 30}}, 10, 10},
+		{"reset discards reference", []pos{{10, 20}, {15, 25}, {-1, -1}, {500, 500}, {505, 505}}, 10, 10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(Window)
+			for _, p := range tt.moves {
+				if p == (pos{-1, -1}) {
+					w.resetMouseDelta()
+					continue
+				}
+				w.mouseDelta.move(p.x, p.y)
+			}
+			if dx, dy := w.MouseDelta(); dx != tt.wantDX || dy != tt.wantDY {
+				t.Errorf("got (%v, %v), want (%v, %v)", dx, dy, tt.wantDX, tt.wantDY)
+			}
+			if dx, dy := w.MouseDelta(); dx != 0 || dy != 0 {
+				t.Errorf("second call: got (%v, %v), want (0, 0)", dx, dy)
+			}
+		})
+	}
+}
+
+func TestRawMouseLook(t *testing.T) {
+	previous := rawMouseMotionSupported
+	defer func() { rawMouseMotionSupported = previous }()
+
+	rawMouseMotionSupported = func() bool { return true }
+	w := new(Window)
+	win := &fakeNativeWindow{cursorMode: glfw.CursorNormal}
+	if !w.enableRawMouseLook(win) {
+		t.Fatal("supported raw mouse motion reported as unsupported")
+	}
+	if win.cursorMode != glfw.CursorDisabled || win.inputModes[glfw.RawMouseMotion] != glfw.True || !w.cursorDisabled {
+		t.Errorf("enabled: got cursor mode %#x and raw motion %d", win.cursorMode, win.inputModes[glfw.RawMouseMotion])
+	}
+	w.disableRawMouseLook(win)
+	if win.cursorMode != glfw.CursorNormal || win.inputModes[glfw.RawMouseMotion] != glfw.False || w.cursorDisabled {
+		t.Errorf("disabled: got cursor mode %#x and raw motion %d", win.cursorMode, win.inputModes[glfw.RawMouseMotion])
+	}
+
+	rawMouseMotionSupported = func() bool { return false }
+	win = &fakeNativeWindow{cursorMode: glfw.CursorNormal}
+	if w.enableRawMouseLook(win) {
+		t.Error("unsupported raw mouse motion reported as supported")
+	}
+	if win.cursorMode != glfw.CursorNormal || len(win.inputModes) != 0 {
+		t.Error("input modes changed although raw mouse motion is unsupported")
+	}
+
+	defer stubEnqueue()()
+	if err := w.EnableRawMouseLook(); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("got error %v, want ErrUnsupported", err)
+	}
+}
+
+func TestClampCursorToContent(t *testing.T) {
+	tests := []struct {
+		name       string
+		clamp      bool
+		cursorMode int
+		pos, want  CursorPosEvent
+	}{
+		{"inside", true, CursorNormal, CursorPosEvent{X: 10, Y: 20}, CursorPosEvent{X: 10, Y: 20}},
+		{"before top-left", true, CursorNormal, CursorPosEvent{X: -2, Y: -0.5}, CursorPosEvent{X: 0, Y: 0}},
+		{"after bottom-right", true, CursorHidden, CursorPosEvent{X: 801, Y: 650}, CursorPosEvent{X: 800, Y: 600}},
+		{"disabled cursor", true, CursorDisabled, CursorPosEvent{X: -200, Y: 5000}, CursorPosEvent{X: -200, Y: 5000}},
+		{"clamping disabled", false, CursorNormal, CursorPosEvent{X: -2, Y: 601}, CursorPosEvent{X: -2, Y: 601}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(Window)
+			w.onSize(800, 600)
+			w.trackCursorMode(tt.cursorMode)
+			w.SetClampCursorToContent(tt.clamp)
+			var got CursorPosEvent
+			w.SetCursorPosCallback(func(_ *Window, x, y float64) {
+				got = CursorPosEvent{X: x, Y: y}
+			})
+
+			w.dispatch(tt.pos)
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+// +build !js
+
+package glfw
+
+// OverflowPolicy defines what happens to events that don't fit into a full input channel.
+type OverflowPolicy int
+
+const (
+	// DropOnOverflow discards events that don't fit into the channel.
+	DropOnOverflow OverflowPolicy = iota
+	// BlockOnOverflow waits until the channel has room for the event.
+	// Event processing of the render thread is stalled in the meantime.
+	BlockOnOverflow
+)
+
+// inputChannel is a subscription to the input events of a window.
+type inputChannel struct {
+	events chan Event
+	done   chan struct{} // Closed when unsubscribed.
+}
+
+func (c *inputChannel) send(ev Event, policy OverflowPolicy) {
+	if policy == BlockOnOverflow {
+		select {
+		case c.events <- ev:
+		case <-c.done:
+		}
+		return
+	}
+
+	select {
+	case c.events <- ev:
+	case <-c.done:
+	default:
+	}
+}
+
+// InputChannel returns a channel receiving the input events of this window,
+// and a function to unsubscribe.
+//
+// Each call creates an independent subscription, allowing every window to be served by its own goroutine.
+// Events are delivered in addition to the regular callbacks.
+// When the buffer is full, events are dropped or block according to the window's overflow policy.
+// The channel is not closed when unsubscribing.
+func (w *Window) InputChannel(bufferSize int) (<-chan Event, func()) {
+	c := &inputChannel{
+		events: make(chan Event, bufferSize),
+		done:   make(chan struct{}),
+	}
+
+	w.mu.Lock()
+	w.inputChannels = append(w.inputChannels, c)
+	w.mu.Unlock()
+
+	unsubscribe := func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		for i, other := range w.inputChannels {
+			if other == c {
+				// Copy, since dispatch might still iterate over the old slice.
+				channels := make([]*inputChannel, 0, len(w.inputChannels)-1)
+				channels = append(channels, w.inputChannels[:i]...)
+				w.inputChannels = append(channels, w.inputChannels[i+1:]...)
+				close(c.done)
+				return
+			}
+		}
+	}
+	return c.events, unsubscribe
+}
+
+// SetInputOverflowPolicy defines how input channels of this window handle events once their buffer is full.
+// The default is DropOnOverflow.
+func (w *Window) SetInputOverflowPolicy(policy OverflowPolicy) {
+	w.mu.Lock()
+	w.overflowPolicy = policy
+	w.mu.Unlock()
+}
@@ -0,0 +1,78 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestInputChannelSend(t *testing.T) {
+	tests := []struct {
+		name         string
+		bufferSize   int
+		policy       OverflowPolicy
+		unsubscribed bool
+		sent         int
+		want         int // Number of buffered events.
+	}{
+		{"fits", 3, DropOnOverflow, false, 2, 2},
+		{"drops on overflow", 2, DropOnOverflow, false, 5, 2},
+		{"blocking fits", 3, BlockOnOverflow, false, 3, 3},
+		{"blocking unsubscribed", 0, BlockOnOverflow, true, 3, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &inputChannel{events: make(chan Event, tt.bufferSize), done: make(chan struct{})}
+			if tt.unsubscribed {
+				close(c.done)
+			}
+			sent := make(chan struct{})
+			go func() {
+				defer close(sent)
+				for i := 0; i < tt.sent; i++ {
+					c.send(CursorPosEvent{X: float64(i)}, tt.policy)
+				}
+			}()
+			select {
+			case <-sent:
+			case <-time.After(time.Second):
+				t.Fatal("send blocked")
+			}
+			if got := len(c.events); got != tt.want {
+				t.Errorf("got %d buffered events, want %d", got, tt.want)
+			}
+			if tt.want > 0 {
+				if ev := <-c.events; ev != (CursorPosEvent{X: 0}) {
+					t.Errorf("first event: got %v, want the first one sent", ev)
+				}
+			}
+		})
+	}
+}
+
+func TestInputChannelSendBlocksOnOverflow(t *testing.T) {
+	c := &inputChannel{events: make(chan Event, 1), done: make(chan struct{})}
+	c.send(CursorPosEvent{X: 1}, BlockOnOverflow)
+
+	sent := make(chan struct{})
+	go func() {
+		defer close(sent)
+		c.send(CursorPosEvent{X: 2}, BlockOnOverflow)
+	}()
+	select {
+	case <-sent:
+		t.Fatal("send didn't block on a full channel")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	<-c.events
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("send didn't resume once the channel had room")
+	}
+	if ev := <-c.events; ev != (CursorPosEvent{X: 2}) {
+		t.Errorf("got %v, want the blocked event", ev)
+	}
+}
@@ -0,0 +1,64 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// Joystick corresponds to a joystick.
+type Joystick glfw.Joystick
+
+const (
+	Joystick1    = Joystick(glfw.Joystick1)
+	Joystick2    = Joystick(glfw.Joystick2)
+	Joystick3    = Joystick(glfw.Joystick3)
+	Joystick4    = Joystick(glfw.Joystick4)
+	Joystick5    = Joystick(glfw.Joystick5)
+	Joystick6    = Joystick(glfw.Joystick6)
+	Joystick7    = Joystick(glfw.Joystick7)
+	Joystick8    = Joystick(glfw.Joystick8)
+	Joystick9    = Joystick(glfw.Joystick9)
+	Joystick10   = Joystick(glfw.Joystick10)
+	Joystick11   = Joystick(glfw.Joystick11)
+	Joystick12   = Joystick(glfw.Joystick12)
+	Joystick13   = Joystick(glfw.Joystick13)
+	Joystick14   = Joystick(glfw.Joystick14)
+	Joystick15   = Joystick(glfw.Joystick15)
+	Joystick16   = Joystick(glfw.Joystick16)
+	JoystickLast = Joystick(glfw.JoystickLast)
+)
+
+// Present reports whether the joystick is present.
+func (j Joystick) Present() bool {
+	var present bool
+	enqueue(true, func() {
+		present = glfw.Joystick(j).Present()
+	})
+	return present
+}
+
+// Capabilities returns the number of axes, buttons and hats of the joystick, all retrieved within a single
+// round-trip to the render thread. ok is false if the joystick is not present.
+//
+// This is intended for laying out input bindings; use it instead of fetching the individual states just to count them.
+func (j Joystick) Capabilities() (axes, buttons, hats int, ok bool) {
+	enqueue(true, func() {
+		axes, buttons, hats, ok = joystickCapabilities(glfw.Joystick(j))
+	})
+	return axes, buttons, hats, ok
+}
+
+// joystickState is the part of glfw.Joystick used by joystickCapabilities.
+type joystickState interface {
+	Present() bool
+	GetAxes() []float32
+	GetButtons() []glfw.Action
+	GetHats() []glfw.JoystickHatState
+}
+
+// joystickCapabilities counts the axes, buttons and hats of the joystick. Must be called on the render thread.
+func joystickCapabilities(joy joystickState) (axes, buttons, hats int, ok bool) {
+	if !joy.Present() {
+		return 0, 0, 0, false
+	}
+	return len(joy.GetAxes()), len(joy.GetButtons()), len(joy.GetHats()), true
+}
@@ -0,0 +1,43 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+type fakeJoystick struct {
+	present bool
+	axes    []float32
+	buttons []glfw.Action
+	hats    []glfw.JoystickHatState
+}
+
+func (j fakeJoystick) Present() bool                    { return j.present }
+func (j fakeJoystick) GetAxes() []float32               { return j.axes }
+func (j fakeJoystick) GetButtons() []glfw.Action        { return j.buttons }
+func (j fakeJoystick) GetHats() []glfw.JoystickHatState { return j.hats }
+
+func TestJoystickCapabilities(t *testing.T) {
+	tests := []struct {
+		name                            string
+		joy                             fakeJoystick
+		wantAxes, wantButtons, wantHats int
+		wantOK                          bool
+	}{
+		{"not present", fakeJoystick{axes: make([]float32, 2)}, 0, 0, 0, false},
+		{"gamepad", fakeJoystick{true, make([]float32, 6), make([]glfw.Action, 15), make([]glfw.JoystickHatState, 1)}, 6, 15, 1, true},
+		{"no inputs", fakeJoystick{present: true}, 0, 0, 0, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			axes, buttons, hats, ok := joystickCapabilities(tt.joy)
+			if axes != tt.wantAxes || buttons != tt.wantButtons || hats != tt.wantHats || ok != tt.wantOK {
+				t.Errorf("got (%d, %d, %d, %v), want (%d, %d, %d, %v)",
+					axes, buttons, hats, ok, tt.wantAxes, tt.wantButtons, tt.wantHats, tt.wantOK)
+			}
+		})
+	}
+}
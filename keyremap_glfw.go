@@ -0,0 +1,48 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// SetKeyRemap translates keys of incoming key events through remap, before they are processed in any other way.
+// Keys without entry in remap are passed through unchanged. Passing nil removes the remapping.
+//
+// Remapping affects everything that observes key events, including event filters, KeyDown, callbacks and input channels.
+// The scancode is not remapped, so GetKeyName(KeyUnknown, scancode) still returns the label of the physical key.
+func (w *Window) SetKeyRemap(remap map[Key]Key) {
+	var copied map[Key]Key
+	if remap != nil {
+		copied = make(map[Key]Key, len(remap))
+		for from, to := range remap {
+			copied[from] = to
+		}
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.keyRemap = copied
+}
+
+// ScancodeCallback is the function signature for scancode callback functions.
+type ScancodeCallback func(w *Window, scancode int, action Action)
+
+// SetScancodeCallback sets the scancode callback, which is called for every key event with its platform-specific scancode.
+//
+// Unlike the key callback, it also reports keys that glfw can't map to a Key, which are reported as KeyUnknown.
+// Scancodes are delivered as reported by the platform, before key remapping and event filters are applied.
+func (w *Window) SetScancodeCallback(cbfun ScancodeCallback) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scancodeCallback = cbfun
+}
+
+// GetKeyName returns the layout-specific name of a printable key.
+// If key is KeyUnknown, the key is identified by scancode instead.
+// An empty string is returned for non-printable keys.
+func GetKeyName(key Key, scancode int) string {
+	var name string
+	enqueue(true, func() {
+		name = glfw.GetKeyName(glfw.Key(key), scancode)
+	})
+	return name
+}
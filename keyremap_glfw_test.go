@@ -0,0 +1,62 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestSetKeyRemap(t *testing.T) {
+	w := new(Window)
+	log := logCallbacks(w)
+
+	remap := map[Key]Key{KeyCapsLock: KeyEscape}
+	w.SetKeyRemap(remap)
+	remap[KeyA] = KeyB // The window keeps its own copy.
+
+	w.dispatch(KeyEvent{Key: KeyCapsLock, Scancode: 66, Action: Press})
+	if !w.KeyDown(KeyEscape) || w.KeyDown(KeyCapsLock) {
+		t.Error("remapped key wasn't tracked as the target key")
+	}
+	w.dispatch(KeyEvent{Key: KeyA, Scancode: 38, Action: Press})
+	w.SetKeyRemap(nil)
+	w.dispatch(KeyEvent{Key: KeyCapsLock, Scancode: 66, Action: Release})
+
+	want := []string{
+		"key 256 66 1 0", // KeyEscape, with the scancode of caps lock.
+		"key 65 38 1 0",
+		"key 280 66 0 0",
+	}
+	if !reflect.DeepEqual(*log, want) {
+		t.Errorf("got callbacks %q, want %q", *log, want)
+	}
+}
+
+func TestSetScancodeCallback(t *testing.T) {
+	w := new(Window)
+	var order []string
+	w.SetScancodeCallback(func(_ *Window, scancode int, action Action) {
+		order = append(order, fmt.Sprintf("scancode %d %d", scancode, action))
+	})
+	w.SetKeyRemap(map[Key]Key{KeyCapsLock: KeyEscape})
+	w.SetEventFilter(func(ev Event) (Event, bool) {
+		order = append(order, "filter")
+		return ev, ev.(KeyEvent).Key != KeyEscape // Drops the remapped key.
+	})
+	w.SetKeyCallback(func(_ *Window, key Key, scancode int, action Action, _ ModifierKey) {
+		order = append(order, fmt.Sprintf("key %d %d", key, scancode))
+	})
+
+	w.dispatch(KeyEvent{Key: KeyUnknown, Scancode: 135, Action: Press})
+	w.dispatch(KeyEvent{Key: KeyCapsLock, Scancode: 66, Action: Release})
+
+	want := []string{
+		"scancode 135 1", "filter", "key -1 135",
+		"scancode 66 0", "filter", // Reported even though the filter drops the event.
+	}
+	if !reflect.DeepEqual(order, want) {
+		t.Errorf("got %q, want %q", order, want)
+	}
+}
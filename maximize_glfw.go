@@ -0,0 +1,79 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// maximizeTimeout is the maximum duration CreateMaximizedWindow waits for the window to be maximized.
+const maximizeTimeout = 500 * time.Millisecond
+
+// CreateMaximizedWindow creates a windowed mode window that is initially maximized.
+// width and height define the size of the window once it is restored.
+//
+// With the Maximized hint alone, window managers often maximize new windows asynchronously,
+// so GetSize still reports the unmaximized size right after creation.
+// CreateMaximizedWindow processes events until the first size change arrives,
+// so the window reports its maximized size once the function returns.
+// If no size change arrives within a short timeout, for example because the window is hidden
+// or the window manager ignores the request, it returns anyway.
+func CreateMaximizedWindow(width, height int, title string) (*Window, error) {
+	if enqueue == nil {
+		return nil, fmt.Errorf("create maximized window: %w", ErrNotInitialized)
+	}
+
+	resized := make(chan struct{}, 1)
+	var err error
+	var window *Window
+	enqueue(true, func() {
+		windowHint(glfw.Maximized, glfw.True)
+		window, err = createWindow(width, height, title, nil, nil)
+		windowHint(glfw.Maximized, hintValue(Maximized, glfw.False))
+		if err != nil {
+			return
+		}
+
+		if window.Window.GetAttrib(glfw.Maximized) == glfw.True {
+			if w, h := window.Window.GetSize(); w != width || h != height {
+				resized <- struct{}{} // Already maximized synchronously.
+			}
+		}
+	})
+	if err != nil {
+		return nil, fmt.Errorf("create maximized window: %w", err)
+	}
+
+	unsubscribe := window.OnResize(func(*Window, int, int) {
+		select {
+		case resized <- struct{}{}:
+		default:
+		}
+	})
+	defer unsubscribe()
+
+	awaitResize(resized, maximizeTimeout, func() {
+		enqueueEvents(true, func() {
+			glfw.WaitEventsTimeout(0.01)
+			flushCoalescedEvents()
+		})
+	})
+	return window, nil
+}
+
+// awaitResize calls processEvents until resized receives or the timeout expires. It reports whether a size change arrived.
+func awaitResize(resized <-chan struct{}, timeout time.Duration, processEvents func()) bool {
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		select {
+		case <-resized:
+			return true
+		default:
+		}
+		processEvents()
+	}
+	return false
+}
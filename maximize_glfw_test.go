@@ -0,0 +1,43 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAwaitResize(t *testing.T) {
+	tests := []struct {
+		name      string
+		resizeAt  int // Number of processed events after which the size changes; -1 for never.
+		wantCalls int
+		want      bool
+	}{
+		{"already resized", 0, 0, true},
+		{"resized while processing events", 3, 3, true},
+		{"timeout", -1, -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resized := make(chan struct{}, 1)
+			if tt.resizeAt == 0 {
+				resized <- struct{}{}
+			}
+			calls := 0
+			got := awaitResize(resized, 50*time.Millisecond, func() {
+				calls++
+				if calls == tt.resizeAt {
+					resized <- struct{}{}
+				}
+				time.Sleep(time.Millisecond)
+			})
+			if got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if tt.wantCalls >= 0 && calls != tt.wantCalls {
+				t.Errorf("got %d calls, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
@@ -0,0 +1,27 @@
+// +build darwin,!js
+
+package glfw
+
+/*
+#cgo CFLAGS: -x objective-c
+#cgo LDFLAGS: -framework Cocoa
+#import <Cocoa/Cocoa.h>
+
+static void setDocumentEdited(void *window, int edited) {
+	[(NSWindow *)window setDocumentEdited:edited];
+}
+*/
+import "C"
+
+// modifiedMarker is empty, as the native document-modified indicator is used instead.
+const modifiedMarker = ""
+
+// setDocumentEdited shows or hides the document-modified indicator in the close button of the window.
+// Must be called on the render thread.
+func setDocumentEdited(w *Window, edited bool) {
+	var e C.int
+	if edited {
+		e = 1
+	}
+	C.setDocumentEdited(w.Window.GetCocoaWindow(), e)
+}
@@ -0,0 +1,9 @@
+// +build !darwin,!js
+
+package glfw
+
+// modifiedMarker is prepended to the title of windows with unsaved changes.
+const modifiedMarker = "* "
+
+// setDocumentEdited does nothing, as there is no native document-modified indicator on this platform.
+func setDocumentEdited(w *Window, edited bool) {}
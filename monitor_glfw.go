@@ -0,0 +1,252 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"sync"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// PeripheralEvent corresponds to a peripheral (monitor or joystick) configuration event.
+type PeripheralEvent glfw.PeripheralEvent
+
+const (
+	Connected    = PeripheralEvent(glfw.Connected)
+	Disconnected = PeripheralEvent(glfw.Disconnected)
+)
+
+// MonitorCallback is the function signature for monitor configuration callback functions.
+type MonitorCallback func(monitor *Monitor, event PeripheralEvent)
+
+var (
+	monitorCallbackMu sync.Mutex
+	monitorCallback   MonitorCallback
+)
+
+// SetMonitorCallback sets the monitor configuration callback, which is called
+// each time a monitor is connected to or disconnected from the system.
+// The callback is called on the render thread.
+func SetMonitorCallback(cbfun MonitorCallback) {
+	monitorCallbackMu.Lock()
+	monitorCallback = cbfun
+	monitorCallbackMu.Unlock()
+}
+
+// installMonitorCallback registers the glfw monitor callback.
+// Must be called on the render thread after glfw has been initialized.
+func installMonitorCallback() {
+	glfw.SetMonitorCallback(func(m *glfw.Monitor, event glfw.PeripheralEvent) {
+		handleMonitorEvent(m, &Monitor{Monitor: m}, PeripheralEvent(event))
+	})
+}
+
+// handleMonitorEvent reapplies the persisted gamma ramp to a connected monitor and calls the monitor callback.
+// native is the glfw monitor wrapped by monitor. Must be called on the render thread.
+func handleMonitorEvent(native gammaMonitor, monitor *Monitor, event PeripheralEvent) {
+	if event == Connected {
+		reapplyPersistedGamma(native)
+	}
+
+	monitorCallbackMu.Lock()
+	cbfun := monitorCallback
+	monitorCallbackMu.Unlock()
+
+	if cbfun != nil {
+		cbfun(monitor, event)
+	}
+}
+
+// GetMonitors returns all currently connected monitors.
+func GetMonitors() []*Monitor {
+	var monitors []*glfw.Monitor
+	enqueue(true, func() {
+		monitors = glfw.GetMonitors()
+	})
+	wrapped := make([]*Monitor, len(monitors))
+	for i, m := range monitors {
+		wrapped[i] = &Monitor{Monitor: m}
+	}
+	return wrapped
+}
+
+// PrimaryVideoMode returns the current video mode of the primary monitor,
+// or nil if no monitor is connected.
+func PrimaryVideoMode() *VidMode {
+	var mode *VidMode
+	enqueue(true, func() {
+		m := primaryMonitor()
+		if m == nil {
+			return
+		}
+		if vm := m.GetVideoMode(); vm != nil {
+			v := toVidMode(vm)
+			mode = &v
+		}
+	})
+	return mode
+}
+
+// videoModeMonitor is the part of a glfw monitor reporting its video mode.
+type videoModeMonitor interface {
+	GetVideoMode() *glfw.VidMode
+}
+
+// primaryMonitor returns the primary monitor, or nil if no monitor is connected. Replaced by tests.
+var primaryMonitor = func() videoModeMonitor {
+	if m := getPrimaryMonitor(); m != nil {
+		return m
+	}
+	return nil
+}
+
+// FindVideoMode returns the supported video mode closest to the requested one, or nil if the monitor reports no modes.
+//
+// An exact match is preferred. Otherwise, the mode with the nearest resolution is chosen,
+// and among those the one with the nearest refresh rate.
+// If refreshRate is zero or DontCare, the highest available refresh rate is chosen.
+func (m *Monitor) FindVideoMode(width, height, refreshRate int) *VidMode {
+	var modes []VidMode
+	enqueue(true, func() {
+		for _, vm := range m.Monitor.GetVideoModes() {
+			modes = append(modes, toVidMode(vm))
+		}
+	})
+	return nearestVideoMode(modes, width, height, refreshRate)
+}
+
+func toVidMode(vm *glfw.VidMode) VidMode {
+	return VidMode{
+		Width:       vm.Width,
+		Height:      vm.Height,
+		RedBits:     vm.RedBits,
+		GreenBits:   vm.GreenBits,
+		BlueBits:    vm.BlueBits,
+		RefreshRate: vm.RefreshRate,
+	}
+}
+
+// defaultRefreshRate is assumed if the refresh rate of a monitor is unknown.
+const defaultRefreshRate = 60
+
+// CurrentMonitor returns the monitor the window is on, or nil if no monitor is connected.
+//
+// For full screen windows, this is the monitor they are full screen on.
+// Otherwise, it is the monitor with the largest overlap with the window's content area,
+// or the primary monitor if the window doesn't overlap any.
+func (w *Window) CurrentMonitor() *Monitor {
+	var m *glfw.Monitor
+	enqueue(true, func() {
+		m = w.currentMonitor()
+	})
+	if m == nil {
+		return nil
+	}
+	return &Monitor{Monitor: m}
+}
+
+// currentMonitor returns the monitor the window is on. Must be called on the render thread.
+func (w *Window) currentMonitor() *glfw.Monitor {
+	if m := w.Window.GetMonitor(); m != nil {
+		return m
+	}
+
+	best, bestArea := getPrimaryMonitor(), 0
+	w.forEachOverlap(func(m *glfw.Monitor, overlap image.Rectangle) {
+		if area := overlap.Dx() * overlap.Dy(); area > bestArea {
+			best, bestArea = m, area
+		}
+	})
+	return best
+}
+
+// OverlappingMonitors returns all monitors that intersect the window's content area, in the order glfw reports them.
+//
+// A window straddling multiple monitors may need to render for the highest content scale among them.
+// The result is empty if the window is entirely off-screen.
+func (w *Window) OverlappingMonitors() []*Monitor {
+	var monitors []*Monitor
+	enqueue(true, func() {
+		w.forEachOverlap(func(m *glfw.Monitor, _ image.Rectangle) {
+			monitors = append(monitors, &Monitor{Monitor: m})
+		})
+	})
+	return monitors
+}
+
+// forEachOverlap calls fn for each monitor that intersects the window's content area, with the intersection in screen coordinates.
+// Must be called on the render thread.
+func (w *Window) forEachOverlap(fn func(m *glfw.Monitor, overlap image.Rectangle)) {
+	x, y := w.Window.GetPos()
+	width, height := w.Window.GetSize()
+	window := image.Rect(x, y, x+width, y+height)
+
+	monitors := glfw.GetMonitors()
+	bounds := make([]image.Rectangle, len(monitors))
+	for i, m := range monitors {
+		if vm := m.GetVideoMode(); vm != nil { // Left empty otherwise, which never intersects.
+			mx, my := m.GetPos()
+			bounds[i] = image.Rect(mx, my, mx+vm.Width, my+vm.Height)
+		}
+	}
+	forEachIntersection(window, bounds, func(i int, overlap image.Rectangle) {
+		fn(monitors[i], overlap)
+	})
+}
+
+// forEachIntersection calls fn with the index of each of the bounds that intersect r, and the intersection.
+func forEachIntersection(r image.Rectangle, bounds []image.Rectangle, fn func(i int, overlap image.Rectangle)) {
+	for i, b := range bounds {
+		if overlap := r.Intersect(b); !overlap.Empty() {
+			fn(i, overlap)
+		}
+	}
+}
+
+// RefreshRate returns the refresh rate, in Hz, of the monitor the window is on (see CurrentMonitor).
+// If it is unknown, 60 Hz is assumed.
+func (w *Window) RefreshRate() int {
+	rate := defaultRefreshRate
+	enqueue(true, func() {
+		m := w.currentMonitor()
+		if m == nil {
+			return
+		}
+		if vm := m.GetVideoMode(); vm != nil && vm.RefreshRate > 0 {
+			rate = vm.RefreshRate
+		}
+	})
+	return rate
+}
+
+// ContentScale returns the content scale of the monitor, which is the ratio between the current DPI and the platform's default DPI.
+//
+// Unlike the content scale of a window, it is available before a window is created,
+// allowing the initial window size to be scaled for the monitor the window will be placed on.
+func (m *Monitor) ContentScale() (x, y float32) {
+	enqueue(true, func() {
+		x, y = monitorContentScale(m.Monitor)
+	})
+	return x, y
+}
+
+// monitorContentScale returns the content scale of a glfw monitor. Replaced by tests.
+var monitorContentScale = (*glfw.Monitor).GetContentScale
+
+// monitorVideoMode returns the current video mode of a glfw monitor. Replaced by tests.
+var monitorVideoMode = (*glfw.Monitor).GetVideoMode
+
+// ColorDepth returns the color depth of the monitor's current video mode, which is the sum of its red, green and blue bits.
+// It returns 0 if the video mode is unknown.
+//
+// Requesting a matching framebuffer format for full screen windows avoids mode switches on some systems.
+func (m *Monitor) ColorDepth() int {
+	var depth int
+	enqueue(true, func() {
+		if vm := monitorVideoMode(m.Monitor); vm != nil {
+			depth = vm.RedBits + vm.GreenBits + vm.BlueBits
+		}
+	})
+	return depth
+}
@@ -0,0 +1,118 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"reflect"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+type fakeVideoModeMonitor glfw.VidMode
+
+func (m *fakeVideoModeMonitor) GetVideoMode() *glfw.VidMode { return (*glfw.VidMode)(m) }
+
+func TestPrimaryVideoMode(t *testing.T) {
+	defer stubEnqueue()()
+	previous := primaryMonitor
+	defer func() { primaryMonitor = previous }()
+
+	primaryMonitor = func() videoModeMonitor {
+		return &fakeVideoModeMonitor{Width: 2560, Height: 1440, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 144}
+	}
+	want := VidMode{Width: 2560, Height: 1440, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 144}
+	if got := PrimaryVideoMode(); got == nil || *got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	primaryMonitor = func() videoModeMonitor { return nil }
+	if got := PrimaryVideoMode(); got != nil {
+		t.Errorf("without monitor: got %v, want nil", got)
+	}
+}
+
+func TestGetPrimaryMonitorHeadless(t *testing.T) {
+	defer stubEnqueue()()
+	previous := getPrimaryMonitor
+	defer func() { getPrimaryMonitor = previous }()
+	getPrimaryMonitor = func() *glfw.Monitor { return nil }
+
+	if m := GetPrimaryMonitor(); m != nil {
+		t.Errorf("got %v, want nil", m)
+	}
+	if mode := PrimaryVideoMode(); mode != nil {
+		t.Errorf("got video mode %v, want nil", mode)
+	}
+	new(Window).SetFullscreen(GetPrimaryMonitor()) // Ignored without panicking.
+}
+
+func TestMonitorContentScale(t *testing.T) {
+	defer stubEnqueue()()
+	previous := monitorContentScale
+	defer func() { monitorContentScale = previous }()
+	monitorContentScale = func(*glfw.Monitor) (float32, float32) { return 1.5, 1.5 }
+
+	x, y := (&Monitor{Monitor: new(glfw.Monitor)}).ContentScale()
+	if x != 1.5 || y != 1.5 {
+		t.Fatalf("got scale (%v, %v), want (1.5, 1.5)", x, y)
+	}
+	// Pre-scaling the window size before creating the window on the monitor.
+	if width, height := int(800*x), int(600*y); width != 1200 || height != 900 {
+		t.Errorf("got pre-scaled size %dx%d, want 1200x900", width, height)
+	}
+}
+
+func TestMonitorColorDepth(t *testing.T) {
+	defer stubEnqueue()()
+	previous := monitorVideoMode
+	defer func() { monitorVideoMode = previous }()
+
+	m := &Monitor{Monitor: new(glfw.Monitor)}
+	for _, vm := range []*glfw.VidMode{
+		{RedBits: 8, GreenBits: 8, BlueBits: 8},
+		{RedBits: 5, GreenBits: 6, BlueBits: 5},
+		{RedBits: 10, GreenBits: 10, BlueBits: 10},
+	} {
+		monitorVideoMode = func(*glfw.Monitor) *glfw.VidMode { return vm }
+		if got, want := m.ColorDepth(), vm.RedBits+vm.GreenBits+vm.BlueBits; got != want {
+			t.Errorf("%d/%d/%d bits: got depth %d, want %d", vm.RedBits, vm.GreenBits, vm.BlueBits, got, want)
+		}
+	}
+
+	monitorVideoMode = func(*glfw.Monitor) *glfw.VidMode { return nil }
+	if got := m.ColorDepth(); got != 0 {
+		t.Errorf("unknown video mode: got depth %d, want 0", got)
+	}
+}
+
+func TestForEachIntersection(t *testing.T) {
+	monitors := []image.Rectangle{
+		image.Rect(0, 0, 1920, 1080),
+		image.Rect(1920, -200, 4480, 1240), // Right of the first one, with a different resolution.
+		{},                                 // Unknown video mode.
+	}
+	tests := []struct {
+		name   string
+		window image.Rectangle
+		want   map[int]image.Rectangle
+	}{
+		{"straddling", image.Rect(1800, 100, 2200, 400), map[int]image.Rectangle{
+			0: image.Rect(1800, 100, 1920, 400),
+			1: image.Rect(1920, 100, 2200, 400),
+		}},
+		{"within one", image.Rect(100, 100, 500, 400), map[int]image.Rectangle{0: image.Rect(100, 100, 500, 400)}},
+		{"touching the edge", image.Rect(1520, 1080, 2020, 1380), map[int]image.Rectangle{1: image.Rect(1920, 1080, 2020, 1240)}},
+		{"off-screen", image.Rect(-600, -400, -100, -100), map[int]image.Rectangle{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := make(map[int]image.Rectangle)
+			forEachIntersection(tt.window, monitors, func(i int, overlap image.Rectangle) { got[i] = overlap })
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+// +build !js
+
+package glfw
+
+// EventObservers registers observers for window events.
+//
+// In contrast to the Set*Callback methods, which replace the previous callback,
+// any number of observers can be registered for the same event,
+// allowing independent systems like game logic, debug overlays and input recorders to receive the same events.
+// Observers are called on the render thread in registration order, after the callback set for the event.
+// Each On method returns a function that unregisters the observer.
+type EventObservers struct {
+	w *Window
+}
+
+// Observe returns the event observers of the window.
+func (w *Window) Observe() *EventObservers {
+	return &EventObservers{w: w}
+}
+
+// OnKey registers a key event observer.
+func (o *EventObservers) OnKey(fn KeyCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnChar registers a character event observer.
+func (o *EventObservers) OnChar(fn CharCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnMouseButton registers a mouse button event observer.
+func (o *EventObservers) OnMouseButton(fn MouseButtonCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnScroll registers a scroll event observer.
+func (o *EventObservers) OnScroll(fn ScrollCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnCursorPos registers a cursor position event observer.
+func (o *EventObservers) OnCursorPos(fn CursorPosCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnFocus registers an observer for focus changes.
+func (o *EventObservers) OnFocus(fn FocusCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnResize registers an observer for window size changes. It is equivalent to Window.OnResize.
+func (o *EventObservers) OnResize(fn SizeCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// OnClose registers an observer that is called when the user attempts to close the window.
+func (o *EventObservers) OnClose(fn CloseCallback) func() {
+	return o.w.addObserver(fn)
+}
+
+// observer is a registered observer function, like a KeyCallback.
+// Observers are compared by identity, as functions aren't comparable.
+type observer struct {
+	fn interface{}
+}
+
+// addObserver registers the observer function and returns a function unregistering it.
+func (w *Window) addObserver(fn interface{}) func() {
+	o := &observer{fn: fn}
+
+	w.mu.Lock()
+	w.observers = append(w.observers, o)
+	w.mu.Unlock()
+
+	return func() {
+		w.mu.Lock()
+		defer w.mu.Unlock()
+
+		for i, other := range w.observers {
+			if other == o {
+				// Copy, since events might still be delivered using the old slice.
+				observers := make([]*observer, 0, len(w.observers)-1)
+				observers = append(observers, w.observers[:i]...)
+				w.observers = append(observers, w.observers[i+1:]...)
+				return
+			}
+		}
+	}
+}
+
+// notifyInput calls the observer if it observes the type of the input event.
+func (o *observer) notifyInput(w *Window, ev Event) {
+	switch ev := ev.(type) {
+	case KeyEvent:
+		if fn, ok := o.fn.(KeyCallback); ok {
+			fn(w, ev.Key, ev.Scancode, ev.Action, ev.Mods)
+		}
+	case CharEvent:
+		if fn, ok := o.fn.(CharCallback); ok {
+			fn(w, ev.Char)
+		}
+	case MouseButtonEvent:
+		if fn, ok := o.fn.(MouseButtonCallback); ok {
+			fn(w, ev.Button, ev.Action, ev.Mods)
+		}
+	case CursorPosEvent:
+		if fn, ok := o.fn.(CursorPosCallback); ok {
+			fn(w, ev.X, ev.Y)
+		}
+	case ScrollEvent:
+		if fn, ok := o.fn.(ScrollCallback); ok {
+			fn(w, ev.XOff, ev.YOff)
+		}
+	}
+}
+
+func (w *Window) onClose() {
+	w.mu.Lock()
+	cbfun := w.closeCallback
+	observers := w.observers
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w)
+	}
+	for _, o := range observers {
+		if fn, ok := o.fn.(CloseCallback); ok {
+			fn(w)
+		}
+	}
+
+	// Callbacks may veto closing by resetting the flag.
+	if w.Window.ShouldClose() {
+		w.signalClosed()
+	}
+}
@@ -0,0 +1,62 @@
+// +build !js
+
+package glfw
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+func TestEventObservers(t *testing.T) {
+	w := new(Window)
+	var calls []string
+	w.SetKeyCallback(func(_ *Window, key Key, _ int, _ Action, _ ModifierKey) {
+		calls = append(calls, fmt.Sprintf("callback key %d", key))
+	})
+
+	observe := w.Observe()
+	unregisterKey := observe.OnKey(func(_ *Window, key Key, _ int, _ Action, _ ModifierKey) {
+		calls = append(calls, fmt.Sprintf("observer key %d", key))
+	})
+	observe.OnKey(func(_ *Window, key Key, _ int, _ Action, _ ModifierKey) {
+		calls = append(calls, fmt.Sprintf("second observer key %d", key))
+	})
+	observe.OnChar(func(_ *Window, char rune) {
+		calls = append(calls, fmt.Sprintf("char %q", char))
+	})
+	observe.OnMouseButton(func(_ *Window, button MouseButton, action Action, _ ModifierKey) {
+		calls = append(calls, fmt.Sprintf("button %d %d", button, action))
+	})
+	observe.OnCursorPos(func(_ *Window, x, y float64) {
+		calls = append(calls, fmt.Sprintf("cursor %v %v", x, y))
+	})
+	observe.OnScroll(func(_ *Window, xoff, yoff float64) {
+		calls = append(calls, fmt.Sprintf("scroll %v %v", xoff, yoff))
+	})
+	observe.OnResize(func(_ *Window, width, height int) {
+		calls = append(calls, fmt.Sprintf("resize %d %d", width, height))
+	})
+
+	w.dispatch(KeyEvent{Key: KeyA, Action: Press})
+	w.dispatch(CharEvent{Char: 'a'})
+	w.dispatch(MouseButtonEvent{Button: MouseButtonMiddle, Action: Release})
+	w.dispatch(CursorPosEvent{X: 1, Y: 2})
+	w.dispatch(ScrollEvent{XOff: 3, YOff: 4})
+	w.onSize(5, 6)
+	unregisterKey()
+	w.dispatch(KeyEvent{Key: KeyB, Action: Press})
+
+	want := []string{
+		"callback key 65", "observer key 65", "second observer key 65",
+		`char 'a'`,
+		"button 2 0",
+		"cursor 1 2",
+		"scroll 3 4",
+		"resize 5 6",
+		"callback key 66", "second observer key 66",
+	}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %q, want %q", calls, want)
+	}
+}
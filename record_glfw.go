@@ -0,0 +1,154 @@
+// +build !js
+
+package glfw
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// recordingVersion is the version of the recording format written by StartRecording.
+//
+// Recordings are JSON lines: a header object with the format version,
+// followed by one object per event with its time in seconds since the recording started.
+const recordingVersion = 1
+
+type recordingHeader struct {
+	Version int `json:"version"`
+}
+
+type recordedEvent struct {
+	Time     float64 `json:"t"`
+	Type     string  `json:"type"` // key, char, button, cursor or scroll.
+	Key      Key     `json:"key,omitempty"`
+	Scancode int     `json:"scancode,omitempty"`
+	Button   int     `json:"button,omitempty"`
+	Action   int     `json:"action,omitempty"`
+	Mods     int     `json:"mods,omitempty"`
+	Char     rune    `json:"char,omitempty"`
+	X        float64 `json:"x,omitempty"`
+	Y        float64 `json:"y,omitempty"`
+}
+
+// inputRecorder writes the events received by a window.
+type inputRecorder struct {
+	mu    sync.Mutex
+	enc   *json.Encoder
+	start time.Time
+	err   error // First write error.
+}
+
+// StartRecording records all input events received by the window to out, until StopRecording is called.
+// An ongoing recording is stopped first.
+//
+// Events are recorded as received from glfw or injected, before key remapping and event filters are applied,
+// so that ReplayInput reproduces the input session. Events are written on the render thread, so out should be fast,
+// for example a buffered file.
+func (w *Window) StartRecording(out io.Writer) {
+	r := &inputRecorder{
+		enc:   json.NewEncoder(out),
+		start: time.Now(),
+	}
+	r.err = r.enc.Encode(recordingHeader{Version: recordingVersion})
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.recorder = r
+}
+
+// StopRecording stops recording input events and returns the first error that occurred while writing, if any.
+func (w *Window) StopRecording() error {
+	w.mu.Lock()
+	r := w.recorder
+	w.recorder = nil
+	w.mu.Unlock()
+
+	if r == nil {
+		return nil
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.err
+}
+
+func (r *inputRecorder) record(ev Event) {
+	var rec recordedEvent
+	switch ev := ev.(type) {
+	case KeyEvent:
+		rec = recordedEvent{Type: "key", Key: ev.Key, Scancode: ev.Scancode, Action: int(ev.Action), Mods: int(ev.Mods)}
+	case CharEvent:
+		rec = recordedEvent{Type: "char", Char: ev.Char}
+	case MouseButtonEvent:
+		rec = recordedEvent{Type: "button", Button: int(ev.Button), Action: int(ev.Action), Mods: int(ev.Mods)}
+	case CursorPosEvent:
+		rec = recordedEvent{Type: "cursor", X: ev.X, Y: ev.Y}
+	case ScrollEvent:
+		rec = recordedEvent{Type: "scroll", X: ev.XOff, Y: ev.YOff}
+	default:
+		return
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+	rec.Time = time.Since(r.start).Seconds()
+	r.err = r.enc.Encode(rec)
+}
+
+// ReplayInput replays a recording written by StartRecording into the window, using the Inject methods.
+// The original timing between events is reproduced; ReplayInput blocks until all events have been injected.
+func ReplayInput(w *Window, in io.Reader) error {
+	return ReplayInputWithSpeed(w, in, 1)
+}
+
+// ReplayInputWithSpeed is like ReplayInput, but speeds up the replay by the given factor.
+// For example, a speed of 2 replays twice as fast. A speed of zero or less injects all events without delay.
+func ReplayInputWithSpeed(w *Window, in io.Reader, speed float64) error {
+	dec := json.NewDecoder(bufio.NewReader(in))
+
+	var header recordingHeader
+	if err := dec.Decode(&header); err != nil {
+		return fmt.Errorf("read recording header: %w", err)
+	}
+	if header.Version != recordingVersion {
+		return fmt.Errorf("unsupported recording version %d", header.Version)
+	}
+
+	start := time.Now()
+	for {
+		var rec recordedEvent
+		if err := dec.Decode(&rec); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return fmt.Errorf("read recorded event: %w", err)
+		}
+
+		if speed > 0 {
+			due := start.Add(time.Duration(rec.Time / speed * float64(time.Second)))
+			time.Sleep(time.Until(due))
+		}
+
+		switch rec.Type {
+		case "key":
+			w.InjectKey(rec.Key, rec.Scancode, Action(rec.Action), ModifierKey(rec.Mods))
+		case "char":
+			w.InjectChar(rec.Char)
+		case "button":
+			w.InjectMouseButton(MouseButton(rec.Button), Action(rec.Action), ModifierKey(rec.Mods))
+		case "cursor":
+			w.InjectCursorPos(rec.X, rec.Y)
+		case "scroll":
+			w.InjectScroll(rec.X, rec.Y)
+		default:
+			return fmt.Errorf("unknown recorded event type %q", rec.Type)
+		}
+	}
+}
@@ -0,0 +1,106 @@
+// +build !js
+
+package glfw
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"testing"
+)
+
+// logCallbacks registers input callbacks on the window that append a description of each call to the returned log.
+func logCallbacks(w *Window) *[]string {
+	var log []string
+	w.SetKeyCallback(func(_ *Window, key Key, scancode int, action Action, mods ModifierKey) {
+		log = append(log, fmt.Sprintf("key %d %d %d %d", key, scancode, action, mods))
+	})
+	w.SetCharCallback(func(_ *Window, char rune) {
+		log = append(log, fmt.Sprintf("char %q", char))
+	})
+	w.SetMouseButtonCallback(func(_ *Window, button MouseButton, action Action, mods ModifierKey) {
+		log = append(log, fmt.Sprintf("button %d %d %d", button, action, mods))
+	})
+	w.SetCursorPosCallback(func(_ *Window, x, y float64) {
+		log = append(log, fmt.Sprintf("cursor %v %v", x, y))
+	})
+	w.SetScrollCallback(func(_ *Window, xoff, yoff float64) {
+		log = append(log, fmt.Sprintf("scroll %v %v", xoff, yoff))
+	})
+	return &log
+}
+
+func TestRecordReplay(t *testing.T) {
+	defer stubEnqueue()()
+
+	events := []Event{
+		KeyEvent{Key: KeyA, Scancode: 38, Action: Press, Mods: ModShift},
+		CharEvent{Char: 'Ä'},
+		KeyEvent{Key: KeyA, Scancode: 38, Action: Release},
+		CursorPosEvent{X: 12.5, Y: -3},
+		MouseButtonEvent{Button: MouseButtonRight, Action: Press, Mods: ModControl},
+		MouseButtonEvent{Button: MouseButtonRight, Action: Release},
+		ScrollEvent{XOff: 0, YOff: -1.5},
+	}
+
+	recorded := new(Window)
+	want := logCallbacks(recorded)
+	var recording bytes.Buffer
+	recorded.StartRecording(&recording)
+	for _, ev := range events {
+		recorded.dispatch(ev)
+	}
+	if err := recorded.StopRecording(); err != nil {
+		t.Fatalf("stop recording: %v", err)
+	}
+	if len(*want) != len(events) {
+		t.Fatalf("recorded window got %d callbacks, want %d", len(*want), len(events))
+	}
+
+	// The recording consists of a header line and one line per event.
+	var lines int
+	scanner := bufio.NewScanner(bytes.NewReader(recording.Bytes()))
+	for scanner.Scan() {
+		if !json.Valid(scanner.Bytes()) {
+			t.Errorf("line %d is no JSON object: %s", lines, scanner.Bytes())
+		}
+		lines++
+	}
+	if lines != len(events)+1 {
+		t.Errorf("got %d lines, want %d", lines, len(events)+1)
+	}
+
+	for _, speed := range []float64{0, -1, 1000} {
+		replayed := new(Window)
+		got := logCallbacks(replayed)
+		if err := ReplayInputWithSpeed(replayed, bytes.NewReader(recording.Bytes()), speed); err != nil {
+			t.Fatalf("speed %v: replay: %v", speed, err)
+		}
+		if !reflect.DeepEqual(*got, *want) {
+			t.Errorf("speed %v: got callbacks %q, want %q", speed, *got, *want)
+		}
+	}
+}
+
+func TestReplayInputErrors(t *testing.T) {
+	defer stubEnqueue()()
+
+	tests := []struct {
+		name      string
+		recording string
+	}{
+		{"empty", ""},
+		{"unsupported version", `{"version":2}`},
+		{"unknown type", `{"version":1}` + "\n" + `{"t":0,"type":"touch"}`},
+		{"malformed event", `{"version":1}` + "\n" + `{"t":`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ReplayInputWithSpeed(new(Window), bytes.NewReader([]byte(tt.recording)), 0); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// SetInteractiveRegions makes the window transparent to mouse input, except within the given regions.
+// This is intended for overlays that should be click-through everywhere but over their controls.
+// Regions are in screen coordinates, relative to the top-left corner of the content area.
+// Passing no regions disables passthrough again.
+//
+// Passthrough is toggled as the cursor moves, using cursor position events.
+// While passthrough is enabled, the window doesn't receive cursor events, so RunEventLoop
+// additionally polls the cursor position to notice when a region is re-entered, waking up periodically if idle.
+// Therefore, windows with interactive regions must be driven by RunEventLoop; otherwise, passthrough is never disabled again.
+//
+// Like SetMousePassthrough, this requires glfw 3.4 and is ignored otherwise.
+func (w *Window) SetInteractiveRegions(regions []image.Rectangle) {
+	if !versionAtLeast(3, 4) {
+		return
+	}
+	regions = append([]image.Rectangle(nil), regions...)
+
+	w.mu.Lock()
+	w.interactiveRegions = regions
+	w.mu.Unlock()
+
+	enqueue(false, func() {
+		w.updatePassthrough(w.Window.GetCursorPos())
+	})
+}
+
+// handleInteractiveRegions toggles passthrough on cursor position events.
+// Must be called on the render thread.
+func (w *Window) handleInteractiveRegions(ev Event) {
+	if ev, ok := ev.(CursorPosEvent); ok {
+		w.updatePassthrough(ev.X, ev.Y)
+	}
+}
+
+// pollInteractiveRegions toggles passthrough based on the current cursor position.
+// Must be called on the render thread.
+func (w *Window) pollInteractiveRegions() {
+	w.mu.Lock()
+	enabled := w.interactiveRegions != nil || w.passthrough
+	w.mu.Unlock()
+
+	if enabled {
+		w.updatePassthrough(w.Window.GetCursorPos())
+	}
+}
+
+// updatePassthrough enables passthrough if the cursor is outside all interactive regions.
+// Must be called on the render thread.
+func (w *Window) updatePassthrough(x, y float64) {
+	w.mu.Lock()
+	passthrough := passthroughAt(w.interactiveRegions, x, y)
+	changed := passthrough != w.passthrough
+	w.passthrough = passthrough
+	w.mu.Unlock()
+
+	if !changed {
+		return
+	}
+	value := glfw.False
+	if passthrough {
+		value = glfw.True
+	}
+	w.Window.SetAttrib(glfw.Hint(MousePassthrough), value)
+}
+
+// passthroughAt reports whether passthrough should be enabled with the cursor at the given position.
+func passthroughAt(regions []image.Rectangle, x, y float64) bool {
+	p := image.Pt(int(x), int(y))
+	for _, r := range regions {
+		if p.In(r) {
+			return false
+		}
+	}
+	return len(regions) > 0
+}
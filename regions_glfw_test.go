@@ -0,0 +1,31 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"testing"
+)
+
+func TestPassthroughAt(t *testing.T) {
+	regions := []image.Rectangle{image.Rect(0, 0, 100, 50), image.Rect(200, 200, 300, 300)}
+	tests := []struct {
+		name    string
+		regions []image.Rectangle
+		x, y    float64
+		want    bool
+	}{
+		{"inside first region", regions, 10, 10, false},
+		{"inside second region", regions, 250, 299.5, false},
+		{"outside", regions, 150, 10, true},
+		{"on exclusive edge", regions, 100, 10, true},
+		{"no regions", nil, 150, 10, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := passthroughAt(tt.regions, tt.x, tt.y); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
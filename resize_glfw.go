@@ -0,0 +1,41 @@
+// +build !js
+
+package glfw
+
+// OnResize registers an observer that is called whenever the window is resized, in screen coordinates.
+// Unlike SetSizeCallback, any number of observers can be registered independently; they are called in registration order,
+// after the size callback. The returned function unregisters the observer.
+func (w *Window) OnResize(fn func(w *Window, width, height int)) func() {
+	return w.addObserver(SizeCallback(fn))
+}
+
+func (w *Window) onSize(width int, height int) {
+	w.mu.Lock()
+	w.redrawOnEvent = true
+	w.fbRatio.width, w.fbRatio.height = width, height
+	w.fbRatio.update()
+	cbfun := w.sizeCallback
+	observers := w.observers
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, width, height)
+	}
+	for _, o := range observers {
+		if fn, ok := o.fn.(SizeCallback); ok {
+			fn(w, width, height)
+		}
+	}
+}
+
+// SetSuppressZeroFramebuffer defines whether framebuffer size events with a zero width or height are suppressed.
+//
+// Some platforms report a framebuffer size of 0x0 when the window is iconified, which breaks renderers
+// that create framebuffers of that size or divide by it. By default, such events are suppressed,
+// and the framebuffer size callback is called again once the size is non-zero.
+// Pass false to receive the raw events. This also applies to resize rendering and EmitInitialFramebufferSize.
+func (w *Window) SetSuppressZeroFramebuffer(suppress bool) {
+	w.mu.Lock()
+	w.rawFramebufferSize = !suppress
+	w.mu.Unlock()
+}
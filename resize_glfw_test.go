@@ -0,0 +1,110 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestOnResize(t *testing.T) {
+	w := new(Window)
+	var calls []string
+	w.SetSizeCallback(func(_ *Window, width, height int) {
+		calls = append(calls, "callback")
+	})
+	unregisterFirst := w.OnResize(func(_ *Window, width, height int) {
+		calls = append(calls, "first")
+	})
+	w.OnResize(func(_ *Window, width, height int) {
+		if width != 640 || height != 480 {
+			t.Errorf("got size %dx%d, want 640x480", width, height)
+		}
+		calls = append(calls, "second")
+	})
+
+	w.onSize(640, 480)
+	unregisterFirst()
+	unregisterFirst() // No effect.
+	w.onSize(640, 480)
+
+	want := []string{"callback", "first", "second", "callback", "second"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("got calls %q, want %q", calls, want)
+	}
+}
+
+func TestSuppressZeroFramebuffer(t *testing.T) {
+	tests := []struct {
+		name     string
+		suppress bool
+		sizes    [][2]int
+		want     [][2]int
+	}{
+		{"suppressed", true, [][2]int{{800, 600}, {0, 0}, {800, 0}, {0, 600}, {800, 600}}, [][2]int{{800, 600}, {800, 600}}},
+		{"raw", false, [][2]int{{800, 600}, {0, 0}, {800, 600}}, [][2]int{{800, 600}, {0, 0}, {800, 600}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := new(Window)
+			w.SetSuppressZeroFramebuffer(tt.suppress)
+			var got [][2]int
+			w.SetFramebufferSizeCallback(func(_ *Window, width, height int) {
+				got = append(got, [2]int{width, height})
+			})
+			var renders int
+			w.resizeRender = func(*Window) { renders++ }
+			for _, size := range tt.sizes {
+				w.onFramebufferSize(size[0], size[1])
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+			if renders != len(tt.want) {
+				t.Errorf("rendered %d times, want %d", renders, len(tt.want))
+			}
+		})
+	}
+}
+
+func TestEmitFramebufferSize(t *testing.T) {
+	w := new(Window)
+	var got [][2]int
+	w.SetFramebufferSizeCallback(func(_ *Window, width, height int) {
+		got = append(got, [2]int{width, height})
+	})
+	var renders int
+	w.resizeRender = func(*Window) { renders++ }
+
+	w.emitFramebufferSize(&fakeNativeWindow{width: 640, height: 480, scale: 2})
+	if w.fbRatio.fbWidth != 1280 || w.fbRatio.fbHeight != 960 {
+		t.Errorf("tracked framebuffer size %dx%d, want 1280x960", w.fbRatio.fbWidth, w.fbRatio.fbHeight)
+	}
+	w.emitFramebufferSize(&fakeNativeWindow{}) // Iconified, suppressed like events.
+	if want := [][2]int{{1280, 960}}; !reflect.DeepEqual(got, want) || renders != 1 {
+		t.Errorf("got sizes %v and %d renders, want %v and 1 render", got, renders, want)
+	}
+}
+
+func TestResizeRenderCallback(t *testing.T) {
+	w := new(Window)
+	var calls []string
+	w.SetFramebufferSizeCallback(func(_ *Window, width, height int) {
+		calls = append(calls, "size")
+	})
+	w.SetResizeRenderCallback(func(*Window) { calls = append(calls, "render") })
+
+	w.onFramebufferSize(800, 600)
+	w.onRefresh()
+	if want := []string{"size", "render", "render"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("got %v, want %v", calls, want)
+	}
+
+	calls = nil
+	w.SetResizeRenderCallback(nil)
+	w.onFramebufferSize(1024, 768)
+	w.onRefresh()
+	if want := []string{"size"}; !reflect.DeepEqual(calls, want) {
+		t.Errorf("after removing the callback: got %v, want %v", calls, want)
+	}
+}
@@ -0,0 +1,33 @@
+// +build !js
+
+package glfw
+
+// ScaleChangeCallback is the function signature for scale change callback functions.
+type ScaleChangeCallback func(w *Window, oldScale, newScale float32)
+
+// SetScaleChangeCallback sets a callback that is called when the content scale of the window changes,
+// for example when it is moved to a monitor with a different DPI.
+// In contrast to the content scale callback, it reports the previous scale as well,
+// which helps to resize cached DPI-dependent resources like font atlases.
+// Scales are horizontal content scales; the vertical scale is the same on all common platforms.
+func (w *Window) SetScaleChangeCallback(cbfun ScaleChangeCallback) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.scaleChangeCallback = cbfun
+}
+
+func (w *Window) onContentScale(x float32, y float32) {
+	w.mu.Lock()
+	oldScale := w.contentScale
+	w.contentScale = x
+	cbfun := w.contentScaleCallback
+	scaleChangeCallback := w.scaleChangeCallback
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, x, y)
+	}
+	if scaleChangeCallback != nil && oldScale != x {
+		scaleChangeCallback(w, oldScale, x)
+	}
+}
@@ -0,0 +1,27 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestScaleChangeCallback(t *testing.T) {
+	w := &Window{contentScale: 1}
+	var changes [][2]float32
+	w.SetScaleChangeCallback(func(_ *Window, oldScale, newScale float32) {
+		changes = append(changes, [2]float32{oldScale, newScale})
+	})
+
+	w.onContentScale(2, 2)
+	w.onContentScale(2, 2) // Unchanged.
+	w.onContentScale(1.5, 1.5)
+
+	if want := [][2]float32{{1, 2}, {2, 1.5}}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("got changes %v, want %v", changes, want)
+	}
+	if w.contentScale != 1.5 {
+		t.Errorf("cached scale %v, want 1.5", w.contentScale)
+	}
+}
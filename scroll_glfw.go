@@ -0,0 +1,134 @@
+// +build !js
+
+package glfw
+
+import "math"
+
+// smoothScrollEpsilon is the outstanding scroll offset below which the remainder is emitted at once.
+const smoothScrollEpsilon = 0.001
+
+// maxSmoothScrollDecay is the largest supported decay. Larger values are clamped, so that scrolling always settles.
+const maxSmoothScrollDecay = 0.99
+
+// SmoothScrollCallback is the function signature for smooth scroll callbacks.
+type SmoothScrollCallback func(w *Window, dx, dy float64)
+
+// smoothScroll accumulates raw scroll offsets and releases them gradually, frame by frame.
+type smoothScroll struct {
+	cbfun    SmoothScrollCallback
+	decay    float64
+	pendingX float64
+	pendingY float64
+	lastTick float64 // Time of the last tick in seconds, or a negative value before the first tick.
+}
+
+func (s *smoothScroll) add(xoff, yoff float64) {
+	s.pendingX += xoff
+	s.pendingY += yoff
+}
+
+// pending reports whether offsets are outstanding.
+func (s *smoothScroll) pending() bool {
+	return s.pendingX != 0 || s.pendingY != 0
+}
+
+// step advances the accumulator to the given time and returns the offsets to emit.
+func (s *smoothScroll) step(now float64) (dx, dy float64) {
+	elapsed := now - s.lastTick
+	if s.lastTick < 0 {
+		elapsed = 1.0 / 60
+	}
+	s.lastTick = now
+	if s.pendingX == 0 && s.pendingY == 0 {
+		return 0, 0
+	}
+	if s.decay == 0 { // Smoothing disabled.
+		dx, dy = s.pendingX, s.pendingY
+		s.pendingX, s.pendingY = 0, 0
+		return dx, dy
+	}
+	if elapsed <= 0 {
+		return 0, 0
+	}
+
+	// decay is the fraction that is retained per 1/60 second.
+	retained := math.Pow(s.decay, elapsed*60)
+	dx = s.pendingX * (1 - retained)
+	dy = s.pendingY * (1 - retained)
+	if math.Abs(s.pendingX-dx) < smoothScrollEpsilon && math.Abs(s.pendingY-dy) < smoothScrollEpsilon {
+		dx, dy = s.pendingX, s.pendingY
+	}
+	s.pendingX -= dx
+	s.pendingY -= dy
+	return dx, dy
+}
+
+// SetSmoothScrollCallback sets a callback that receives scroll offsets smoothed over multiple frames.
+//
+// Raw scroll offsets are accumulated and emitted gradually, so that chunky mouse wheel steps
+// and bursts of tiny trackpad deltas both result in fluid, kinetic scrolling.
+// decay is the fraction of the outstanding offset that is retained per 1/60 second. A decay of 0 disables smoothing.
+// Values below 0 and NaN are treated as 0, values above 0.99 as 0.99.
+//
+// The accumulator is advanced by RunEventLoop; fn is called at most once per loop iteration.
+// The regular scroll callback keeps receiving the raw offsets.
+// Passing a nil fn removes the smooth scroll callback.
+func (w *Window) SetSmoothScrollCallback(fn func(w *Window, dx, dy float64), decay float64) {
+	switch {
+	case !(decay >= 0): // Also catches NaN.
+		decay = 0
+	case decay > maxSmoothScrollDecay:
+		decay = maxSmoothScrollDecay
+	}
+
+	w.mu.Lock()
+	if fn == nil {
+		w.smoothScroll = nil
+	} else {
+		w.smoothScroll = &smoothScroll{
+			cbfun:    fn,
+			decay:    decay,
+			lastTick: -1,
+		}
+	}
+	w.mu.Unlock()
+}
+
+// tickSmoothScroll emits the smoothed scroll offsets for the current frame.
+// Must be called on the render thread.
+func (w *Window) tickSmoothScroll(now float64) {
+	w.mu.Lock()
+	s := w.smoothScroll
+	var dx, dy float64
+	if s != nil {
+		dx, dy = s.step(now)
+	}
+	if dx != 0 || dy != 0 {
+		w.redrawOnEvent = true // Draw the scrolled content with RedrawOnEvent.
+	}
+	w.mu.Unlock()
+
+	if s != nil && (dx != 0 || dy != 0) {
+		s.cbfun(w, dx, dy)
+	}
+}
+
+// SetScrollDirection defines the direction of vertical scroll offsets.
+//
+// By default, offsets are reported in the platform's convention.
+// If natural is true, the sign of vertical offsets is inverted before they are delivered to
+// scroll callbacks, smooth scroll callbacks and input channels.
+func (w *Window) SetScrollDirection(natural bool) {
+	w.mu.Lock()
+	w.naturalScroll = natural
+	w.mu.Unlock()
+}
+
+// SetScrollScaleWithDPI defines whether scroll offsets are multiplied by the window's content scale,
+// so that scrolling covers the same physical distance on low and high DPI monitors.
+// Scaling is disabled by default.
+func (w *Window) SetScrollScaleWithDPI(enabled bool) {
+	w.mu.Lock()
+	w.scrollScaleDPI = enabled
+	w.mu.Unlock()
+}
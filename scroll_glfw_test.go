@@ -0,0 +1,114 @@
+// +build !js
+
+package glfw
+
+import (
+	"math"
+	"reflect"
+	"testing"
+)
+
+func TestSmoothScrollStep(t *testing.T) {
+	tests := []struct {
+		name    string
+		decay   float64
+		pending float64
+		times   []float64 // Tick times; the first tick assumes 1/60 seconds elapsed.
+		want    []float64 // Emitted vertical offset per tick.
+	}{
+		{"disabled", 0, 3, []float64{0, 0}, []float64{3, 0}},
+		{"disabled without elapsed time", 0, 3, []float64{1, 1}, []float64{3, 0}},
+		{"half per frame", 0.5, 4, []float64{0, 1.0 / 60, 2.0 / 60}, []float64{2, 1, 0.5}},
+		{"no elapsed time", 0.5, 4, []float64{0, 0}, []float64{2, 0}},
+		{"two frames at once", 0.5, 4, []float64{0, 2.0 / 60}, []float64{2, 1.5}},
+		{"remainder below epsilon", 0.5, 0.001, []float64{0}, []float64{0.001}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := &smoothScroll{decay: tt.decay, lastTick: -1}
+			s.add(0, tt.pending)
+			for i, now := range tt.times {
+				dx, dy := s.step(now)
+				if dx != 0 || math.Abs(dy-tt.want[i]) > 1e-9 {
+					t.Errorf("tick %d: got (%v, %v), want (0, %v)", i, dx, dy, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSetSmoothScrollCallbackDecay(t *testing.T) {
+	fn := func(*Window, float64, float64) {}
+	tests := []struct {
+		decay, want float64
+	}{
+		{-0.1, 0},
+		{math.NaN(), 0},
+		{0, 0},
+		{0.5, 0.5},
+		{0.99, 0.99},
+		{1, maxSmoothScrollDecay},
+		{2, maxSmoothScrollDecay},
+		{math.Inf(1), maxSmoothScrollDecay},
+	}
+	for _, tt := range tests {
+		w := new(Window)
+		w.SetSmoothScrollCallback(fn, tt.decay)
+		if got := w.smoothScroll.decay; got != tt.want {
+			t.Errorf("decay %v: got %v, want %v", tt.decay, got, tt.want)
+		}
+	}
+}
+
+func TestScrollDirection(t *testing.T) {
+	for _, natural := range []bool{false, true} {
+		w := new(Window)
+		w.SetScrollDirection(natural)
+		var scrolled, smoothed [][2]float64
+		w.SetScrollCallback(func(_ *Window, xoff, yoff float64) {
+			scrolled = append(scrolled, [2]float64{xoff, yoff})
+		})
+		w.SetSmoothScrollCallback(func(_ *Window, dx, dy float64) {
+			smoothed = append(smoothed, [2]float64{dx, dy})
+		}, 0)
+		events, unsubscribe := w.InputChannel(4)
+
+		w.dispatch(ScrollEvent{XOff: 1, YOff: 2})
+		w.tickSmoothScroll(0)
+		unsubscribe()
+
+		want := [2]float64{1, 2}
+		if natural {
+			want = [2]float64{1, -2}
+		}
+		if len(scrolled) != 1 || scrolled[0] != want {
+			t.Errorf("natural %v: scroll callback got %v, want %v", natural, scrolled, want)
+		}
+		if len(smoothed) != 1 || smoothed[0] != want {
+			t.Errorf("natural %v: smooth scroll callback got %v, want %v", natural, smoothed, want)
+		}
+		if got := drain(events); len(got) != 1 || got[0] != (ScrollEvent{XOff: want[0], YOff: want[1]}) {
+			t.Errorf("natural %v: input channel got %v, want %v", natural, got, want)
+		}
+	}
+}
+
+func TestScrollScaleWithDPI(t *testing.T) {
+	w := new(Window)
+	w.contentScale = 2 // As tracked on a high-DPI monitor.
+	var scrolled [][2]float64
+	w.SetScrollCallback(func(_ *Window, xoff, yoff float64) {
+		scrolled = append(scrolled, [2]float64{xoff, yoff})
+	})
+
+	w.dispatch(ScrollEvent{XOff: 0.5, YOff: -1})
+	w.SetScrollScaleWithDPI(true)
+	w.dispatch(ScrollEvent{XOff: 0.5, YOff: -1})
+	w.SetScrollScaleWithDPI(false)
+	w.dispatch(ScrollEvent{XOff: 0.5, YOff: -1})
+
+	want := [][2]float64{{0.5, -1}, {1, -2}, {0.5, -1}}
+	if !reflect.DeepEqual(scrolled, want) {
+		t.Errorf("got %v, want %v", scrolled, want)
+	}
+}
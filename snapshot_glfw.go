@@ -0,0 +1,80 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// WindowSnapshot captures the configuration of a window, so it can be restored later on.
+// It can be serialized, for example to JSON, to restore window layouts across sessions.
+type WindowSnapshot struct {
+	X          int         `json:"x"` // Position of the content area, in screen coordinates.
+	Y          int         `json:"y"`
+	Width      int         `json:"width"` // Size of the content area, in screen coordinates.
+	Height     int         `json:"height"`
+	State      WindowState `json:"state"`
+	Monitor    string      `json:"monitor,omitempty"` // Name of the monitor for full screen windows, empty for windowed mode.
+	Opacity    float32     `json:"opacity"`
+	CursorMode int         `json:"cursorMode"`
+}
+
+// Snapshot captures the current configuration of the window.
+func (w *Window) Snapshot() WindowSnapshot {
+	var s WindowSnapshot
+	enqueue(true, func() {
+		s.X, s.Y = w.Window.GetPos()
+		s.Width, s.Height = w.Window.GetSize()
+		s.State = w.queryState()
+		if m := w.Window.GetMonitor(); m != nil {
+			s.Monitor = m.GetName()
+		}
+		s.Opacity = w.Window.GetOpacity()
+		s.CursorMode = w.Window.GetInputMode(glfw.CursorMode)
+	})
+	return s
+}
+
+// RestoreSnapshot applies a previously captured configuration to the window.
+//
+// If the snapshot's full screen monitor is no longer connected or has no video mode, the window is restored in windowed mode.
+func (w *Window) RestoreSnapshot(s WindowSnapshot) {
+	enqueue(false, func() {
+		var monitor *glfw.Monitor
+		var mode *glfw.VidMode
+		if s.Monitor != "" {
+			for _, m := range glfw.GetMonitors() {
+				if m.GetName() == s.Monitor {
+					if mode = m.GetVideoMode(); mode != nil {
+						monitor = m
+					}
+					break
+				}
+			}
+		}
+
+		switch {
+		case monitor != nil:
+			w.setMonitor(monitor, 0, 0, mode.Width, mode.Height, mode.RefreshRate)
+		case w.Window.GetMonitor() != nil:
+			w.setMonitor(nil, s.X, s.Y, s.Width, s.Height, 0)
+		default:
+			w.Window.SetPos(s.X, s.Y)
+			w.Window.SetSize(s.Width, s.Height)
+		}
+
+		switch s.State {
+		case WindowIconified:
+			w.Window.Iconify()
+		case WindowMaximized:
+			w.Window.Maximize()
+		default:
+			if w.queryState() != WindowNormal {
+				w.Window.Restore()
+			}
+		}
+
+		w.Window.SetOpacity(s.Opacity)
+		w.resetMouseDelta()
+		w.Window.SetInputMode(glfw.CursorMode, s.CursorMode)
+		w.trackCursorMode(s.CursorMode)
+	})
+}
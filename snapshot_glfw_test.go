@@ -0,0 +1,46 @@
+// +build !js
+
+package glfw
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestWindowSnapshotJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		snapshot WindowSnapshot
+		want     string
+	}{
+		{
+			"windowed",
+			WindowSnapshot{X: -10, Y: 20, Width: 800, Height: 600, State: WindowMaximized, Opacity: 0.5, CursorMode: CursorHidden},
+			`{"x":-10,"y":20,"width":800,"height":600,"state":2,"opacity":0.5,"cursorMode":212994}`,
+		},
+		{
+			"full screen",
+			WindowSnapshot{Width: 1920, Height: 1080, Monitor: "DP-1", Opacity: 1, CursorMode: CursorNormal},
+			`{"x":0,"y":0,"width":1920,"height":1080,"state":0,"monitor":"DP-1","opacity":1,"cursorMode":212993}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := json.Marshal(tt.snapshot)
+			if err != nil {
+				t.Fatalf("marshal: %v", err)
+			}
+			if string(data) != tt.want {
+				t.Errorf("got %s, want %s", data, tt.want)
+			}
+
+			var decoded WindowSnapshot
+			if err := json.Unmarshal(data, &decoded); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if decoded != tt.snapshot {
+				t.Errorf("round trip: got %+v, want %+v", decoded, tt.snapshot)
+			}
+		})
+	}
+}
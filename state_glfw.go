@@ -0,0 +1,210 @@
+// +build !js
+
+package glfw
+
+import "github.com/go-gl/glfw/v3.3/glfw"
+
+// WindowState describes whether a window is iconified, maximized or neither.
+type WindowState int
+
+const (
+	WindowNormal WindowState = iota
+	WindowIconified
+	WindowMaximized
+)
+
+func (s WindowState) String() string {
+	switch s {
+	case WindowNormal:
+		return "NORMAL"
+	case WindowIconified:
+		return "ICONIFIED"
+	case WindowMaximized:
+		return "MAXIMIZED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+// State returns the tracked state of the window.
+//
+// The state is updated by the iconify and maximize callbacks, so reading it doesn't require a round-trip to the render thread.
+// Changes requested via Iconify, Restore and Maximize are reflected as soon as the corresponding events have been processed.
+func (w *Window) State() WindowState {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.state
+}
+
+// Iconified reports whether the window is iconified, according to its tracked state.
+//
+// The tracked state takes precedence over the Iconified attribute: it is updated before the iconify callback is called,
+// so the callback observes the new state, even on platforms where GetAttrib still reports the old one during the transition.
+func (w *Window) Iconified() bool {
+	return w.State() == WindowIconified
+}
+
+// Maximized reports whether the window is maximized, according to its tracked state.
+//
+// Like Iconified, it prefers the state tracked by the maximize callback over the Maximized attribute.
+func (w *Window) Maximized() bool {
+	return w.State() == WindowMaximized
+}
+
+// Focused reports whether the window has input focus, according to its tracked state.
+//
+// Like Iconified, it prefers the state tracked by the focus callback over the Focused attribute.
+// It is equivalent to HasFocus.
+func (w *Window) Focused() bool {
+	return w.HasFocus()
+}
+
+// installStateCallbacks initializes the tracked window state and registers the glfw callbacks keeping it up to date.
+// Must be called on the render thread.
+func (w *Window) installStateCallbacks() {
+	w.state = w.queryState()
+	w.maximized = w.state == WindowMaximized
+	if w.Window.GetAttrib(glfw.Focused) == glfw.True {
+		w.setFocused(true)
+	}
+
+	w.Window.SetFocusCallback(func(_ *glfw.Window, focused bool) {
+		w.onFocus(focused)
+	})
+
+	w.Window.SetIconifyCallback(func(_ *glfw.Window, iconified bool) {
+		w.onIconify(iconified)
+	})
+	w.Window.SetMaximizeCallback(func(_ *glfw.Window, maximized bool) {
+		w.onMaximize(maximized)
+	})
+}
+
+func (w *Window) onFocus(focused bool) {
+	if !focused {
+		w.input.releaseMouseButtons()
+		w.stopTitleBarDrag()
+	}
+
+	w.setFocused(focused)
+
+	w.mu.Lock()
+	cbfun := w.focusCallback
+	observers := w.observers
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, focused)
+	}
+	for _, o := range observers {
+		if fn, ok := o.fn.(FocusCallback); ok {
+			fn(w, focused)
+		}
+	}
+}
+
+func (w *Window) onIconify(iconified bool) {
+	w.mu.Lock()
+	switch {
+	case iconified && w.state != WindowIconified:
+		w.restoreMaximized = w.state == WindowMaximized
+		w.state = WindowIconified
+	case !iconified && w.state == WindowIconified:
+		w.state = WindowNormal
+		if w.restoreMaximized {
+			w.state = WindowMaximized
+		}
+	}
+	cbfun := w.iconifyCallback
+	changeCallback, maximized, changed := w.takeMaximizeChange()
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, iconified)
+	}
+	if changed && changeCallback != nil {
+		changeCallback(w, maximized)
+	}
+}
+
+func (w *Window) onMaximize(maximized bool) {
+	w.mu.Lock()
+	switch {
+	case w.state == WindowIconified: // Applies once the window is restored.
+		w.restoreMaximized = maximized
+	case maximized:
+		w.state = WindowMaximized
+	case w.state == WindowMaximized:
+		w.state = WindowNormal
+	}
+	cbfun := w.maximizeCallback
+	changeCallback, maximizedState, changed := w.takeMaximizeChange()
+	w.mu.Unlock()
+
+	if cbfun != nil {
+		cbfun(w, maximized)
+	}
+	if changed && changeCallback != nil {
+		changeCallback(w, maximizedState)
+	}
+}
+
+// SetMaximizeChangeCallback sets a callback that is called when the window becomes maximized or stops being maximized,
+// according to its tracked state. Passing nil removes the callback.
+//
+// Unlike the maximize callback, which forwards each event as delivered by the platform, the reported value is
+// derived from the tracked state, and the callback is only called if it actually changed.
+// This accounts for events delivered out of order or redundantly, and for windows that are restored
+// from being iconified directly into the maximized state. Changes while the window is iconified are not reported.
+func (w *Window) SetMaximizeChangeCallback(cbfun func(w *Window, maximized bool)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.maximizeChangeCallback = cbfun
+}
+
+// takeMaximizeChange reports whether the maximized state changed since it was last reported, and records it as reported.
+// w.mu must be held.
+func (w *Window) takeMaximizeChange() (cbfun func(w *Window, maximized bool), maximized bool, changed bool) {
+	if w.state == WindowIconified {
+		return nil, false, false
+	}
+	maximized = w.state == WindowMaximized
+	changed = maximized != w.maximized
+	w.maximized = maximized
+	return w.maximizeChangeCallback, maximized, changed
+}
+
+// queryState reads the current window state from glfw.
+// Must be called on the render thread.
+func (w *Window) queryState() WindowState {
+	switch {
+	case w.Window.GetAttrib(glfw.Iconified) == glfw.True:
+		return WindowIconified
+	case w.Window.GetAttrib(glfw.Maximized) == glfw.True:
+		return WindowMaximized
+	default:
+		return WindowNormal
+	}
+}
+
+// SetPauseWhenUnfocused defines whether rendering should pause while the window is unfocused or iconified.
+// The render loop is expected to consult ShouldRender.
+func (w *Window) SetPauseWhenUnfocused(pause bool) {
+	w.mu.Lock()
+	w.pauseUnfocused = pause
+	w.mu.Unlock()
+}
+
+// ShouldRender reports whether the next frame should be rendered.
+//
+// If pausing is enabled via SetPauseWhenUnfocused, this returns false while the window is unfocused or iconified,
+// allowing the render loop to skip rendering and buffer swaps, or to drop to a lower frame rate.
+// The state is tracked by the focus and iconify callbacks, so this doesn't require a round-trip to the render thread.
+func (w *Window) ShouldRender() bool {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if !w.pauseUnfocused {
+		return true
+	}
+	return w.HasFocus() && w.state != WindowIconified
+}
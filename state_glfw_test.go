@@ -0,0 +1,160 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRedundantStateChanges(t *testing.T) {
+	previous := enqueue
+	defer func() { enqueue = previous }()
+	var enqueued int
+	enqueue = func(_ bool, fn func()) { enqueued++ } // Never call into glfw.
+
+	tests := []struct {
+		name  string
+		state WindowState
+		call  func(w *Window)
+		want  int
+	}{
+		{"iconify iconified", WindowIconified, (*Window).Iconify, 0},
+		{"iconify normal", WindowNormal, (*Window).Iconify, 1},
+		{"iconify maximized", WindowMaximized, (*Window).Iconify, 1},
+		{"restore normal", WindowNormal, (*Window).Restore, 0},
+		{"restore iconified", WindowIconified, (*Window).Restore, 1},
+		{"restore maximized", WindowMaximized, (*Window).Restore, 1},
+		{"maximize maximized", WindowMaximized, (*Window).Maximize, 0},
+		{"maximize normal", WindowNormal, (*Window).Maximize, 1},
+		{"maximize iconified", WindowIconified, (*Window).Maximize, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			enqueued = 0
+			w := &Window{state: tt.state}
+			tt.call(w)
+			if enqueued != tt.want {
+				t.Errorf("enqueued %d commands, want %d", enqueued, tt.want)
+			}
+		})
+	}
+}
+
+func TestTrackedState(t *testing.T) {
+	tests := []struct {
+		name    string
+		initial WindowState
+		events  []string // "iconify", "restore" (iconify callback with false), "maximize" or "unmaximize".
+		want    WindowState
+		changes []bool // Reported by the maximize change callback.
+	}{
+		{"iconify and restore", WindowNormal, []string{"iconify", "restore"}, WindowNormal, nil},
+		{"restore to maximized", WindowMaximized, []string{"iconify", "restore"}, WindowMaximized, nil},
+		{"unmaximized while iconified", WindowMaximized, []string{"iconify", "unmaximize", "restore"}, WindowNormal, []bool{false}},
+		{"maximized while iconified", WindowNormal, []string{"iconify", "maximize"}, WindowIconified, nil},
+		{"restored maximized", WindowNormal, []string{"iconify", "maximize", "restore"}, WindowMaximized, []bool{true}},
+		{"maximize reported after restore", WindowNormal, []string{"iconify", "restore", "maximize"}, WindowMaximized, []bool{true}},
+		{"redundant iconify", WindowMaximized, []string{"iconify", "iconify", "restore"}, WindowMaximized, nil},
+		{"redundant restore", WindowMaximized, []string{"restore"}, WindowMaximized, nil},
+		{"maximize and unmaximize", WindowNormal, []string{"maximize", "unmaximize"}, WindowNormal, []bool{true, false}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Window{state: tt.initial, maximized: tt.initial == WindowMaximized}
+			var changes []bool
+			w.SetMaximizeChangeCallback(func(_ *Window, maximized bool) {
+				changes = append(changes, maximized)
+			})
+			for _, ev := range tt.events {
+				switch ev {
+				case "iconify":
+					w.onIconify(true)
+				case "restore":
+					w.onIconify(false)
+				case "maximize":
+					w.onMaximize(true)
+				case "unmaximize":
+					w.onMaximize(false)
+				}
+			}
+			if got := w.State(); got != tt.want {
+				t.Errorf("got state %v, want %v", got, tt.want)
+			}
+			if !reflect.DeepEqual(changes, tt.changes) {
+				t.Errorf("got maximize changes %v, want %v", changes, tt.changes)
+			}
+		})
+	}
+}
+
+func TestMaximizeChangeCallback(t *testing.T) {
+	w := new(Window)
+	var raw, changes []bool
+	var tracked []bool // Maximized, as seen from within the change callback.
+	w.SetMaximizeCallback(func(_ *Window, maximized bool) {
+		raw = append(raw, maximized)
+	})
+	w.SetMaximizeChangeCallback(func(cw *Window, maximized bool) {
+		if cw != w {
+			t.Errorf("got window %p, want %p", cw, w)
+		}
+		changes = append(changes, maximized)
+		tracked = append(tracked, cw.Maximized())
+	})
+
+	// Redundant events, and a window restored from being iconified directly into the maximized state.
+	w.onMaximize(true)
+	w.onMaximize(true)
+	w.onIconify(true)
+	w.onIconify(false)
+	w.onMaximize(false)
+	w.onMaximize(false)
+	w.onIconify(true)
+	w.onMaximize(true)
+	w.onIconify(false)
+
+	if want := []bool{true, true, false, false, true}; !reflect.DeepEqual(raw, want) {
+		t.Errorf("got maximize events %v, want %v", raw, want)
+	}
+	if want := []bool{true, false, true}; !reflect.DeepEqual(changes, want) {
+		t.Errorf("got maximize changes %v, want %v", changes, want)
+	}
+	if !reflect.DeepEqual(tracked, changes) {
+		t.Errorf("got tracked state %v during the changes %v", tracked, changes)
+	}
+
+	w.SetMaximizeChangeCallback(nil)
+	w.onMaximize(false)
+	if len(changes) != 3 {
+		t.Errorf("removed callback was called with %v", changes[3:])
+	}
+}
+
+func TestShouldRender(t *testing.T) {
+	tests := []struct {
+		name    string
+		pause   bool
+		focused bool
+		state   WindowState
+		want    bool
+	}{
+		{"focused", true, true, WindowNormal, true},
+		{"focused maximized", true, true, WindowMaximized, true},
+		{"unfocused", true, false, WindowNormal, false},
+		{"iconified", true, true, WindowIconified, false},
+		{"pausing disabled", false, false, WindowIconified, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &Window{state: tt.state}
+			if tt.focused {
+				w.focused = 1
+			}
+			w.SetPauseWhenUnfocused(tt.pause)
+			if got := w.ShouldRender(); got != tt.want {
+				t.Errorf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,43 @@
+// +build !js
+
+package glfw
+
+/*
+// renderThreadGeneration is the Init call that marked the current OS thread as the render thread, 0 if none.
+static __thread long renderThreadGeneration;
+
+static void markRenderThread(long generation) {
+	renderThreadGeneration = generation;
+}
+
+static long currentRenderThreadGeneration() {
+	return renderThreadGeneration;
+}
+*/
+import "C"
+import "sync/atomic"
+
+// renderThreadGeneration counts Init calls, so that threads marked by previous render threads aren't accepted.
+// Accessed atomically.
+var renderThreadGeneration int64
+
+// AssertCallbackThread panics if it isn't called on the render thread.
+//
+// It is a development aid for callbacks issuing GL calls, which are only valid on the render thread.
+// The render thread is identified by a thread-local marker set during Init. This relies on the render thread
+// executing all commands on the same OS thread, as required for GL, so no other goroutine can run on it.
+func (w *Window) AssertCallbackThread() {
+	expected := atomic.LoadInt64(&renderThreadGeneration)
+	if expected == 0 {
+		panic("glfw: AssertCallbackThread called before Init")
+	}
+	if int64(C.currentRenderThreadGeneration()) != expected {
+		panic("glfw: callback running outside of the render thread")
+	}
+}
+
+// recordRenderThread marks the current OS thread as the render thread.
+// Must be called on the render thread.
+func recordRenderThread() {
+	C.markRenderThread(C.long(atomic.AddInt64(&renderThreadGeneration, 1)))
+}
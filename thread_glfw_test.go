@@ -0,0 +1,61 @@
+// +build !js
+
+package glfw
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+)
+
+// assertPanics reports whether AssertCallbackThread panics on the current goroutine.
+func assertPanics(w *Window) (panicked bool) {
+	defer func() {
+		panicked = recover() != nil
+	}()
+	w.AssertCallbackThread()
+	return false
+}
+
+func TestAssertCallbackThread(t *testing.T) {
+	w := new(Window)
+	run := make(chan func() bool)
+	result := make(chan bool)
+	go func() { // Emulates a render thread.
+		runtime.LockOSThread()
+		for fn := range run {
+			result <- fn()
+		}
+	}()
+	defer close(run)
+	onRenderThread := func(fn func() bool) bool {
+		run <- fn
+		return <-result
+	}
+
+	if atomic.LoadInt64(&renderThreadGeneration) == 0 && !onRenderThread(func() bool { return assertPanics(w) }) {
+		t.Error("expected panic before Init")
+	}
+	onRenderThread(func() bool { recordRenderThread(); return false })
+
+	if onRenderThread(func() bool { return assertPanics(w) }) {
+		t.Error("unexpected panic on the render thread")
+	}
+	if !assertPanics(w) {
+		t.Error("expected panic outside of the render thread")
+	}
+
+	// A new render thread replaces the old one.
+	newRenderThread := make(chan bool)
+	go func() {
+		runtime.LockOSThread()
+		recordRenderThread()
+		newRenderThread <- assertPanics(w)
+	}()
+	if <-newRenderThread {
+		t.Error("unexpected panic on the new render thread")
+	}
+	if !onRenderThread(func() bool { return assertPanics(w) }) {
+		t.Error("expected panic on the previous render thread")
+	}
+}
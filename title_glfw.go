@@ -0,0 +1,89 @@
+// +build !js
+
+package glfw
+
+import "time"
+
+// throttleTimer applies a pending throttled title.
+type throttleTimer interface {
+	Stop() bool
+}
+
+// titleNow and startTitleTimer provide the time for throttled titles. They are replaceable to control time in tests.
+var (
+	titleNow        = time.Now
+	startTitleTimer = func(d time.Duration, f func()) throttleTimer {
+		return time.AfterFunc(d, f)
+	}
+)
+
+// SetTitleThrottled sets the window title, but applies it at most once per minInterval.
+//
+// It is intended for titles that change rapidly, like progress or FPS displays.
+// Updates arriving within the interval are coalesced; once the interval has passed, the latest title is applied.
+// Calling SetTitle discards pending throttled updates.
+func (w *Window) SetTitleThrottled(title string, minInterval time.Duration) {
+	w.mu.Lock()
+	w.pendingTitle = title
+	if w.titleTimer != nil { // The latest title will be applied when the timer fires.
+		w.mu.Unlock()
+		return
+	}
+	now := titleNow()
+	wait := minInterval - now.Sub(w.titleSetAt)
+	if wait > 0 {
+		w.titleTimer = startTitleTimer(wait, w.flushTitle)
+		w.mu.Unlock()
+		return
+	}
+	w.titleSetAt = now
+	w.mu.Unlock()
+
+	w.setTitle(title)
+}
+
+// flushTitle applies the pending throttled title.
+func (w *Window) flushTitle() {
+	w.mu.Lock()
+	if w.titleTimer == nil { // Cancelled by SetTitle.
+		w.mu.Unlock()
+		return
+	}
+	w.titleTimer = nil
+	w.titleSetAt = titleNow()
+	title := w.pendingTitle
+	w.mu.Unlock()
+
+	w.setTitle(title)
+}
+
+// cancelThrottledTitle discards any pending throttled title update.
+func (w *Window) cancelThrottledTitle() {
+	w.mu.Lock()
+	if w.titleTimer != nil {
+		w.titleTimer.Stop()
+		w.titleTimer = nil
+	}
+	w.mu.Unlock()
+}
+
+// SetModified defines whether the window shows an unsaved-changes marker.
+//
+// On macOS, the native document-modified indicator of the window is used.
+// On other platforms, the title is prefixed with an asterisk. The prefix composes with SetTitle and SetTitleThrottled:
+// titles set later on are prefixed as well, so callers always pass the plain title.
+func (w *Window) SetModified(modified bool) {
+	w.mu.Lock()
+	if w.modified == modified {
+		w.mu.Unlock()
+		return
+	}
+	w.modified = modified
+	title := w.baseTitle
+	w.mu.Unlock()
+
+	enqueue(false, func() {
+		setDocumentEdited(w, modified)
+	})
+	w.setTitle(title)
+}
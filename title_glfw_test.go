@@ -0,0 +1,86 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestSetTitleThrottled(t *testing.T) {
+	now := time.Unix(1000, 0)
+	defer func(previous func() time.Time) { titleNow = previous }(titleNow)
+	titleNow = func() time.Time { return now }
+
+	var timers []*fakeHoverTimer
+	var waits []time.Duration
+	defer func(start func(time.Duration, func()) throttleTimer) { startTitleTimer = start }(startTitleTimer)
+	startTitleTimer = func(d time.Duration, f func()) throttleTimer {
+		timer := &fakeHoverTimer{fire: f}
+		timers = append(timers, timer)
+		waits = append(waits, d)
+		return timer
+	}
+
+	w := new(Window)
+	var applied []string
+	defer func(previous func(bool, func())) { enqueue = previous }(enqueue)
+	enqueue = func(_ bool, fn func()) { applied = append(applied, w.baseTitle) } // Never call into glfw.
+
+	const interval = 100 * time.Millisecond
+	w.SetTitleThrottled("a", interval) // Applied immediately.
+	now = now.Add(10 * time.Millisecond)
+	w.SetTitleThrottled("b", interval)
+	w.SetTitleThrottled("c", interval) // Coalesced with b.
+	if want := []string{"a"}; !reflect.DeepEqual(applied, want) {
+		t.Fatalf("within interval: applied %q, want %q", applied, want)
+	}
+	if want := []time.Duration{90 * time.Millisecond}; !reflect.DeepEqual(waits, want) {
+		t.Fatalf("got timers %v, want %v", waits, want)
+	}
+
+	now = now.Add(90 * time.Millisecond)
+	timers[0].fire()
+	if want := []string{"a", "c"}; !reflect.DeepEqual(applied, want) {
+		t.Fatalf("after interval: applied %q, want %q", applied, want)
+	}
+
+	now = now.Add(200 * time.Millisecond)
+	w.SetTitleThrottled("d", interval) // Interval since c passed.
+	w.SetTitleThrottled("e", interval)
+	w.SetTitle("f") // Discards e.
+	timers[1].fire()
+	if want := []string{"a", "c", "d", "f"}; !reflect.DeepEqual(applied, want) {
+		t.Errorf("after SetTitle: applied %q, want %q", applied, want)
+	}
+	if len(timers) != 2 || !timers[1].stopped {
+		t.Errorf("pending timer wasn't stopped by SetTitle")
+	}
+}
+
+func TestSetModified(t *testing.T) {
+	if runtime.GOOS == "darwin" {
+		t.Skip("uses the native document-modified indicator, which requires a window")
+	}
+	defer stubEnqueue()()
+	defer func(previous func(*glfw.Window, string)) { setWindowTitle = previous }(setWindowTitle)
+	var titles []string
+	setWindowTitle = func(_ *glfw.Window, title string) { titles = append(titles, title) }
+
+	w := new(Window)
+	w.SetTitle("notes.txt")
+	w.SetModified(true)
+	w.SetModified(true) // Unchanged, not applied again.
+	w.SetTitle("draft.txt")
+	w.SetModified(false)
+	w.SetTitle("notes.txt")
+
+	want := []string{"notes.txt", modifiedMarker + "notes.txt", modifiedMarker + "draft.txt", "draft.txt", "notes.txt"}
+	if !reflect.DeepEqual(titles, want) {
+		t.Errorf("got titles %q, want %q", titles, want)
+	}
+}
@@ -0,0 +1,78 @@
+// +build !js
+
+package glfw
+
+// titleBarDrag tracks dragging an undecorated window by a custom title bar.
+type titleBarDrag struct {
+	region         func(x, y float64) bool // Nil if disabled.
+	active         bool
+	startX, startY float64 // Cursor position when the drag started, relative to the content area.
+	cursorKnown    bool    // Whether a cursor position was received yet.
+	cursorX        float64 // Last cursor position, relative to the content area.
+	cursorY        float64
+}
+
+// EnableTitleBarDrag allows moving the window by dragging it with the left mouse button.
+// Drags can only start within the area for which region returns true.
+// Coordinates are in screen coordinates, relative to the top-left corner of the content area.
+//
+// This is intended for undecorated windows that draw their own title bar.
+// Dragging stops when the button is released or the window loses focus.
+// Mouse events are still delivered to the registered callbacks.
+// Passing nil disables dragging.
+func (w *Window) EnableTitleBarDrag(region func(x, y float64) bool) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.titleBarDrag.region = region
+	w.titleBarDrag.active = false
+}
+
+// stopTitleBarDrag aborts an ongoing title bar drag.
+func (w *Window) stopTitleBarDrag() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.titleBarDrag.active = false
+}
+
+// handleTitleBarDrag starts, continues or stops title bar drags.
+// Must be called on the render thread.
+func (w *Window) handleTitleBarDrag(ev Event) {
+	w.mu.Lock()
+	dx, dy, move := w.titleBarDrag.update(ev)
+	w.mu.Unlock()
+
+	if move {
+		moveBy(w.Window, dx, dy)
+	}
+}
+
+// update processes an input event and returns the distance the window has to be moved by, if any.
+// The cursor position of button presses is taken from the preceding cursor position events,
+// so that it matches the event order even if the cursor moved on since.
+func (d *titleBarDrag) update(ev Event) (dx, dy int, move bool) {
+	switch ev := ev.(type) {
+	case MouseButtonEvent:
+		if ev.Button != MouseButtonLeft {
+			return 0, 0, false
+		}
+		switch ev.Action {
+		case Release:
+			d.active = false
+		case Press:
+			if d.region != nil && d.cursorKnown && d.region(d.cursorX, d.cursorY) {
+				d.active = true
+				d.startX, d.startY = d.cursorX, d.cursorY
+			}
+		}
+
+	case CursorPosEvent:
+		d.cursorKnown = true
+		d.cursorX, d.cursorY = ev.X, ev.Y
+		if d.active {
+			// Moving the window keeps the cursor at the same spot within the content area,
+			// so the offset to the starting position is the distance to move.
+			return int(ev.X - d.startX), int(ev.Y - d.startY), true
+		}
+	}
+	return 0, 0, false
+}
@@ -0,0 +1,79 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTitleBarDrag(t *testing.T) {
+	titleBar := func(x, y float64) bool { return y < 20 }
+	press := MouseButtonEvent{Button: MouseButtonLeft, Action: Press}
+	release := MouseButtonEvent{Button: MouseButtonLeft, Action: Release}
+
+	tests := []struct {
+		name   string
+		region func(x, y float64) bool
+		events []Event
+		want   [][2]int // Moves.
+	}{
+		{
+			"press drag release",
+			titleBar,
+			[]Event{
+				CursorPosEvent{X: 50, Y: 10}, press,
+				CursorPosEvent{X: 55, Y: 12}, // Moves the window; the cursor is back at the start position afterwards.
+				CursorPosEvent{X: 50, Y: 10},
+				CursorPosEvent{X: 40.5, Y: 3},
+				release,
+				CursorPosEvent{X: 100, Y: 5},
+			},
+			[][2]int{{5, 2}, {0, 0}, {-9, -7}},
+		},
+		{
+			"press outside region",
+			titleBar,
+			[]Event{CursorPosEvent{X: 50, Y: 30}, press, CursorPosEvent{X: 60, Y: 10}},
+			nil,
+		},
+		{
+			"press position from last cursor event",
+			titleBar,
+			[]Event{CursorPosEvent{X: 50, Y: 30}, CursorPosEvent{X: 50, Y: 10}, press, CursorPosEvent{X: 51, Y: 10}},
+			[][2]int{{1, 0}},
+		},
+		{
+			"unknown cursor position",
+			titleBar,
+			[]Event{press, CursorPosEvent{X: 50, Y: 10}},
+			nil,
+		},
+		{
+			"other button",
+			titleBar,
+			[]Event{CursorPosEvent{X: 50, Y: 10}, MouseButtonEvent{Button: MouseButtonRight, Action: Press}, CursorPosEvent{X: 60, Y: 10}},
+			nil,
+		},
+		{
+			"disabled",
+			nil,
+			[]Event{CursorPosEvent{X: 50, Y: 10}, press, CursorPosEvent{X: 60, Y: 10}},
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d := titleBarDrag{region: tt.region}
+			var moves [][2]int
+			for _, ev := range tt.events {
+				if dx, dy, move := d.update(ev); move {
+					moves = append(moves, [2]int{dx, dy})
+				}
+			}
+			if !reflect.DeepEqual(moves, tt.want) {
+				t.Errorf("got moves %v, want %v", moves, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,70 @@
+// +build !js
+
+package glfw
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// versionAtLeast reports whether the linked glfw library has at least the given version.
+// Features of newer glfw versions degrade gracefully if this returns false.
+func versionAtLeast(major, minor int) bool {
+	maj, min, _ := linkedVersion()
+	return maj > major || (maj == major && min >= minor)
+}
+
+// linkedVersion returns the version of the linked glfw library. Replaced by tests.
+var linkedVersion = glfw.GetVersion
+
+// VersionInfo describes the linked glfw library.
+type VersionInfo struct {
+	Major, Minor, Rev int
+	Backend           string   // Window system backend, like "Win32", "Cocoa", "X11" or "Wayland".
+	Extras            []string // Compile-time options, like the context APIs and timer sources.
+}
+
+// GetVersionInfo returns the version and compile-time configuration of the linked glfw library.
+//
+// It is parsed from the version string. Parts that can't be parsed are left zero.
+func GetVersionInfo() VersionInfo {
+	var str string
+	enqueue(true, func() {
+		str = glfw.GetVersionString()
+	})
+	return parseVersionString(str)
+}
+
+// parseVersionString parses glfw version strings of the form "3.3.0 X11 GLX EGL OSMesa clock_gettime evdev shared".
+func parseVersionString(str string) VersionInfo {
+	var info VersionInfo
+	fields := strings.Fields(str)
+	if len(fields) == 0 {
+		return info
+	}
+
+	version := strings.SplitN(fields[0], ".", 3)
+	numbers := []*int{&info.Major, &info.Minor, &info.Rev}
+	for i, part := range version {
+		// Ignore suffixes, like in "3.4.0-dev".
+		end := strings.IndexFunc(part, func(r rune) bool { return r < '0' || r > '9' })
+		if end >= 0 {
+			part = part[:end]
+		}
+		n, err := strconv.Atoi(part)
+		if err != nil {
+			break
+		}
+		*numbers[i] = n
+	}
+
+	if len(fields) > 1 {
+		info.Backend = fields[1]
+	}
+	if len(fields) > 2 {
+		info.Extras = fields[2:]
+	}
+	return info
+}
@@ -0,0 +1,29 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseVersionString(t *testing.T) {
+	tests := []struct {
+		str  string
+		want VersionInfo
+	}{
+		{"3.3.0 X11 GLX EGL OSMesa clock_gettime evdev shared", VersionInfo{3, 3, 0, "X11", []string{"GLX", "EGL", "OSMesa", "clock_gettime", "evdev", "shared"}}},
+		{"3.3.2 Cocoa NSGL", VersionInfo{3, 3, 2, "Cocoa", []string{"NSGL"}}},
+		{"3.4.0-dev Wayland", VersionInfo{3, 4, 0, "Wayland", nil}},
+		{"3.2", VersionInfo{Major: 3, Minor: 2}},
+		{"3.x.1 Win32", VersionInfo{Major: 3, Backend: "Win32"}},
+		{"", VersionInfo{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.str, func(t *testing.T) {
+			if got := parseVersionString(tt.str); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("got %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
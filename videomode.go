@@ -0,0 +1,33 @@
+package glfw
+
+// nearestVideoMode returns the mode closest to the requested one, or nil if modes is empty.
+//
+// Modes are compared by resolution first, using the squared distance of width and height,
+// and by refresh rate second. A refresh rate of zero or less prefers the highest one.
+// Among equally close modes, the last one wins, which has the highest color depth
+// if modes is sorted like glfw does.
+func nearestVideoMode(modes []VidMode, width, height, refreshRate int) *VidMode {
+	var best *VidMode
+	var bestRes, bestRate int
+	for i := range modes {
+		mode := &modes[i]
+		dw, dh := mode.Width-width, mode.Height-height
+		res := dw*dw + dh*dh
+		rate := -mode.RefreshRate
+		if refreshRate > 0 {
+			rate = abs(mode.RefreshRate - refreshRate)
+		}
+
+		if best == nil || res < bestRes || (res == bestRes && rate <= bestRate) {
+			best, bestRes, bestRate = mode, res, rate
+		}
+	}
+	return best
+}
+
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
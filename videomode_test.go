@@ -0,0 +1,37 @@
+package glfw
+
+import "testing"
+
+func TestNearestVideoMode(t *testing.T) {
+	modes := []VidMode{
+		{Width: 1280, Height: 720, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 60},
+		{Width: 1920, Height: 1080, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 60},
+		{Width: 1920, Height: 1080, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 144},
+		{Width: 1920, Height: 1080, RedBits: 10, GreenBits: 10, BlueBits: 10, RefreshRate: 144},
+		{Width: 2560, Height: 1440, RedBits: 8, GreenBits: 8, BlueBits: 8, RefreshRate: 75},
+	}
+	tests := []struct {
+		name                       string
+		modes                      []VidMode
+		width, height, refreshRate int
+		want                       int // Index into modes, -1 for nil.
+	}{
+		{"no modes", nil, 1920, 1080, 60, -1},
+		{"exact", modes, 1920, 1080, 60, 1},
+		{"nearest resolution", modes, 1300, 700, 60, 0},
+		{"nearest refresh rate", modes, 1920, 1080, 120, 3},
+		{"highest refresh rate", modes, 1920, 1080, 0, 3},
+		{"resolution before refresh rate", modes, 2500, 1400, 144, 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := nearestVideoMode(tt.modes, tt.width, tt.height, tt.refreshRate)
+			switch {
+			case tt.want < 0 && got != nil:
+				t.Errorf("got %+v, want nil", *got)
+			case tt.want >= 0 && got != &tt.modes[tt.want]:
+				t.Errorf("got %+v, want %+v", got, tt.modes[tt.want])
+			}
+		})
+	}
+}
@@ -0,0 +1,58 @@
+// +build !js
+
+package glfw
+
+// WakeupChannel returns a channel that wakes up event processing whenever a value is sent to it,
+// allowing arbitrary Go events, like finished downloads or fired timers, to interrupt WaitEvents.
+//
+// The channel is drained by a goroutine that posts an empty event and flags the window for redrawing,
+// so that RunEventLoop renders a frame even with the RedrawOnEvent policy.
+// Bursts of sends are coalesced: values that are pending while an empty event is being posted
+// only cause a single additional wakeup. Sends block briefly while the goroutine is busy.
+//
+// All calls return the same channel. It must not be sent to after the window has been destroyed.
+func (w *Window) WakeupChannel() chan<- struct{} {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.wakeup == nil {
+		w.wakeup = make(chan struct{}, 1)
+		w.wakeupDone = make(chan struct{})
+		go w.runWakeup(w.wakeup, w.wakeupDone)
+	}
+	return w.wakeup
+}
+
+func (w *Window) runWakeup(wakeup <-chan struct{}, done <-chan struct{}) {
+	for {
+		select {
+		case <-wakeup:
+		case <-done:
+			return
+		}
+		// Coalesce sends that piled up in the meantime.
+	drain:
+		for {
+			select {
+			case <-wakeup:
+			default:
+				break drain
+			}
+		}
+
+		w.mu.Lock()
+		w.redrawOnEvent = true
+		w.mu.Unlock()
+		PostEmptyEvent()
+	}
+}
+
+// stopWakeup terminates the goroutine serving the wakeup channel, if any.
+func (w *Window) stopWakeup() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if w.wakeupDone != nil {
+		close(w.wakeupDone)
+		w.wakeupDone = nil
+	}
+}
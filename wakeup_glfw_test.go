@@ -0,0 +1,52 @@
+// +build !js
+
+package glfw
+
+import (
+	"testing"
+	"time"
+)
+
+func TestWakeupChannel(t *testing.T) {
+	previous := postEmptyEvent
+	defer func() { postEmptyEvent = previous }()
+	woke, release := make(chan struct{}), make(chan struct{})
+	postEmptyEvent = func() {
+		woke <- struct{}{}
+		<-release
+	}
+	awaitWakeup := func() {
+		select {
+		case <-woke:
+		case <-time.After(time.Second):
+			t.Fatal("event processing not woken up")
+		}
+	}
+
+	w := new(Window)
+	wakeup := w.WakeupChannel()
+	defer w.stopWakeup()
+	if w.WakeupChannel() != wakeup {
+		t.Error("got a different channel on the second call")
+	}
+
+	wakeup <- struct{}{}
+	awaitWakeup()
+	w.mu.Lock()
+	redraw := w.redrawOnEvent
+	w.mu.Unlock()
+	if !redraw {
+		t.Error("window not flagged for redrawing")
+	}
+
+	wakeup <- struct{}{} // Pending while the first wakeup is being posted.
+	release <- struct{}{}
+	awaitWakeup()
+	release <- struct{}{}
+
+	select {
+	case <-woke:
+		t.Error("woken up more often than sent to")
+	case <-time.After(10 * time.Millisecond):
+	}
+}
@@ -0,0 +1,87 @@
+// +build !js
+
+package glfw
+
+import "sync"
+
+var (
+	windowsMu sync.Mutex
+	windows   []*Window // All windows that have not been destroyed, in creation order.
+)
+
+// Windows returns all windows that have been created and not destroyed yet, in creation order.
+func Windows() []*Window {
+	windowsMu.Lock()
+	defer windowsMu.Unlock()
+	return append([]*Window(nil), windows...)
+}
+
+func registerWindow(w *Window) {
+	windowsMu.Lock()
+	defer windowsMu.Unlock()
+	windows = append(windows, w)
+}
+
+func unregisterWindow(w *Window) {
+	windowsMu.Lock()
+	defer windowsMu.Unlock()
+	for i, other := range windows {
+		if other == w {
+			windows = append(windows[:i], windows[i+1:]...)
+			return
+		}
+	}
+}
+
+// isRegistered reports whether w has not been destroyed yet.
+// Called on the render thread, it is accurate with respect to previously enqueued commands.
+func isRegistered(w *Window) bool {
+	windowsMu.Lock()
+	defer windowsMu.Unlock()
+	for _, other := range windows {
+		if other == w {
+			return true
+		}
+	}
+	return false
+}
+
+// IconifyAll iconifies all windows.
+// Windows that are already iconified are left alone, so that RestoreAll only restores the windows iconified by IconifyAll.
+func IconifyAll() {
+	enqueue(false, func() {
+		// The windows are read on the render thread, so that windows destroyed by preceding commands are skipped.
+		iconifyAll(Windows(), func(w *Window) { w.Window.Iconify() })
+	})
+}
+
+// iconifyAll iconifies the windows that are not iconified yet using iconify, and marks them for restoreAll.
+// Must be called on the render thread.
+func iconifyAll(windows []*Window, iconify func(w *Window)) {
+	for _, w := range windows {
+		if w.State() == WindowIconified {
+			continue
+		}
+		w.iconifiedByAll = true
+		iconify(w)
+	}
+}
+
+// RestoreAll restores the windows iconified by the last call to IconifyAll.
+// Windows the user iconified independently stay iconified.
+func RestoreAll() {
+	enqueue(false, func() {
+		restoreAll(Windows(), func(w *Window) { w.Window.Restore() })
+	})
+}
+
+// restoreAll restores the windows marked by iconifyAll using restore. Must be called on the render thread.
+func restoreAll(windows []*Window, restore func(w *Window)) {
+	for _, w := range windows {
+		if !w.iconifiedByAll {
+			continue
+		}
+		w.iconifiedByAll = false
+		restore(w)
+	}
+}
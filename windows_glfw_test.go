@@ -0,0 +1,55 @@
+// +build !js
+
+package glfw
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestIconifyAllRestoreAll(t *testing.T) {
+	a, b, c := new(Window), new(Window), new(Window)
+	names := map[*Window]string{a: "a", b: "b", c: "c"}
+	b.state = WindowIconified // Iconified by the user.
+
+	var iconified []string
+	iconifyAll([]*Window{a, b, c}, func(w *Window) {
+		iconified = append(iconified, names[w])
+		w.state = WindowIconified
+	})
+	if want := []string{"a", "c"}; !reflect.DeepEqual(iconified, want) {
+		t.Errorf("iconified %v, want %v", iconified, want)
+	}
+
+	var restored []string
+	restore := func(w *Window) {
+		restored = append(restored, names[w])
+		w.state = WindowNormal
+	}
+	restoreAll([]*Window{a, b, c}, restore)
+	if want := []string{"a", "c"}; !reflect.DeepEqual(restored, want) {
+		t.Errorf("restored %v, want %v", restored, want)
+	}
+	if b.State() != WindowIconified {
+		t.Error("window iconified by the user was restored")
+	}
+
+	restored = nil
+	restoreAll([]*Window{a, b, c}, restore)
+	if len(restored) != 0 {
+		t.Errorf("second RestoreAll restored %v", restored)
+	}
+}
+
+func TestIconifyAllSkipsDestroyedWindows(t *testing.T) {
+	defer stubEnqueue()()
+	defer func(all []*Window) { windows = all }(windows)
+
+	w := new(Window)
+	windows = []*Window{w}
+	unregisterWindow(w) // Like Destroy, which unregisters on the render thread.
+	IconifyAll()        // Would call into glfw for registered windows.
+	if w.iconifiedByAll {
+		t.Error("destroyed window was iconified")
+	}
+}
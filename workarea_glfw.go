@@ -0,0 +1,133 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"sync"
+	"time"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+// WorkareaChangeCallback is the function signature for work area change callback functions.
+// The work area is reported in screen coordinates.
+type WorkareaChangeCallback func(m *Monitor, x, y, width, height int)
+
+var (
+	workareaMu       sync.Mutex
+	workareaCallback WorkareaChangeCallback
+	workareaInterval = time.Second
+	workareaStop     chan struct{} // Closed to stop polling; nil if not polling.
+
+	workareas map[glfw.Monitor]image.Rectangle // Last known work areas. Only accessed on the render thread.
+)
+
+// SetWorkareaChangeCallback sets a callback that is called when the work area of a monitor changes,
+// like when a taskbar auto-hides, an on-screen keyboard appears or a dock is moved.
+// Passing nil removes the callback.
+//
+// glfw doesn't report work area changes, so they are detected by polling the work areas of all monitors
+// at the interval set via SetWorkareaPollInterval. Each poll is a single non-blocking command on the render thread,
+// so changes are reported with a delay of up to one interval. Polling only takes place while a callback is set.
+// The work areas known when the callback is set serve as baseline and aren't reported; neither are newly connected monitors.
+// The callback is called on the render thread. Like most functions, it must not be called before Init.
+func SetWorkareaChangeCallback(cbfun WorkareaChangeCallback) {
+	workareaMu.Lock()
+	defer workareaMu.Unlock()
+	workareaCallback = cbfun
+	restartWorkareaPolling()
+}
+
+// SetWorkareaPollInterval sets the interval at which work areas are polled for SetWorkareaChangeCallback.
+// The default is one second. Zero or negative values are ignored.
+func SetWorkareaPollInterval(interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+	workareaMu.Lock()
+	defer workareaMu.Unlock()
+	workareaInterval = interval
+	restartWorkareaPolling()
+}
+
+// restartWorkareaPolling stops the polling goroutine and starts a new one if a callback is set.
+// workareaMu must be held.
+func restartWorkareaPolling() {
+	if workareaStop != nil {
+		close(workareaStop)
+		workareaStop = nil
+	}
+	if workareaCallback == nil {
+		return
+	}
+	workareaStop = make(chan struct{})
+	go pollWorkareas(workareaInterval, workareaStop)
+}
+
+func pollWorkareas(interval time.Duration, stop <-chan struct{}) {
+	enqueue(false, resetWorkareas)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			enqueue(false, checkWorkareas)
+		case <-stop:
+			return
+		}
+	}
+}
+
+// resetWorkareas records the current work areas as baseline. Must be called on the render thread.
+func resetWorkareas() {
+	workareas = nil
+	checkWorkareas()
+}
+
+// currentWorkareas returns the work areas of all connected monitors, or nil if glfw is not initialized.
+// Monitors are identified by value, since go-gl/glfw returns new pointers on every call.
+// Must be called on the render thread. Replaced in tests.
+var currentWorkareas = func() map[glfw.Monitor]image.Rectangle {
+	if !initialized {
+		return nil
+	}
+	areas := make(map[glfw.Monitor]image.Rectangle)
+	for _, m := range glfw.GetMonitors() {
+		x, y, width, height := m.GetWorkarea()
+		areas[*m] = image.Rect(x, y, x+width, y+height)
+	}
+	return areas
+}
+
+// checkWorkareas compares the work areas of all monitors against the last known ones, and reports changes.
+// Must be called on the render thread.
+func checkWorkareas() {
+	current := currentWorkareas()
+	if current == nil {
+		return
+	}
+
+	workareaMu.Lock()
+	cbfun := workareaCallback
+	workareaMu.Unlock()
+
+	baseline := workareas == nil
+	var changed []glfw.Monitor
+	for m, area := range current {
+		if previous, ok := workareas[m]; ok && previous != area {
+			changed = append(changed, m)
+		}
+	}
+	workareas = current
+
+	if baseline || cbfun == nil {
+		return
+	}
+	for _, m := range changed {
+		m := m
+		area := current[m]
+		cbfun(&Monitor{Monitor: &m}, area.Min.X, area.Min.Y, area.Dx(), area.Dy())
+	}
+}
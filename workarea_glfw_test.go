@@ -0,0 +1,68 @@
+// +build !js
+
+package glfw
+
+import (
+	"image"
+	"testing"
+
+	"github.com/go-gl/glfw/v3.3/glfw"
+)
+
+func TestCheckWorkareas(t *testing.T) {
+	var monitor glfw.Monitor
+	area := image.Rect(0, 0, 1920, 1040)
+	defer func(query func() map[glfw.Monitor]image.Rectangle) {
+		currentWorkareas = query
+		workareaCallback = nil
+		workareas = nil
+	}(currentWorkareas)
+	currentWorkareas = func() map[glfw.Monitor]image.Rectangle {
+		return map[glfw.Monitor]image.Rectangle{monitor: area}
+	}
+
+	var got []image.Rectangle
+	workareaCallback = func(m *Monitor, x, y, width, height int) {
+		if *m.Monitor != monitor {
+			t.Errorf("unexpected monitor %v", m.Monitor)
+		}
+		got = append(got, image.Rect(x, y, x+width, y+height))
+	}
+
+	steps := []struct {
+		name string
+		area image.Rectangle
+		want []image.Rectangle
+	}{
+		{"baseline", image.Rect(0, 0, 1920, 1040), nil},
+		{"unchanged", image.Rect(0, 0, 1920, 1040), nil},
+		{"taskbar hidden", image.Rect(0, 0, 1920, 1080), []image.Rectangle{image.Rect(0, 0, 1920, 1080)}},
+		{"unchanged again", image.Rect(0, 0, 1920, 1080), nil},
+		{"dock moved", image.Rect(80, 0, 1920, 1080), []image.Rectangle{image.Rect(80, 0, 1920, 1080)}},
+	}
+	for i, step := range steps {
+		area, got = step.area, nil
+		if i > 0 {
+			checkWorkareas()
+		} else {
+			resetWorkareas()
+		}
+		if len(got) != len(step.want) || (len(got) > 0 && got[0] != step.want[0]) {
+			t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestCheckWorkareasUninitialized(t *testing.T) {
+	defer func(query func() map[glfw.Monitor]image.Rectangle) {
+		currentWorkareas = query
+		workareas = nil
+	}(currentWorkareas)
+	currentWorkareas = func() map[glfw.Monitor]image.Rectangle { return nil }
+
+	workareas = map[glfw.Monitor]image.Rectangle{{}: image.Rect(0, 0, 1, 1)}
+	checkWorkareas()
+	if len(workareas) != 1 {
+		t.Errorf("work areas were modified while uninitialized")
+	}
+}